@@ -6,21 +6,29 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/control-theory/gonzo/internal/analyzer"
 	"github.com/control-theory/gonzo/internal/filereader"
 	"github.com/control-theory/gonzo/internal/formats"
+	"github.com/control-theory/gonzo/internal/k8s"
 	"github.com/control-theory/gonzo/internal/memory"
+	"github.com/control-theory/gonzo/internal/multiline"
 	"github.com/control-theory/gonzo/internal/otlplog"
 	"github.com/control-theory/gonzo/internal/otlpreceiver"
+	"github.com/control-theory/gonzo/internal/output"
+	"github.com/control-theory/gonzo/internal/syslogreceiver"
 	"github.com/control-theory/gonzo/internal/tui"
 	versioncheck "github.com/control-theory/gonzo/internal/version"
 	"github.com/control-theory/gonzo/internal/vmlogs"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
 )
 
 // runApp initializes and runs the application
@@ -47,42 +55,45 @@ func runApp(cmd *cobra.Command, args []string) error {
 	}
 
 	// Initialize format detector and converter with custom format if specified
-	var formatDetector *otlplog.FormatDetector
-	var logConverter *otlplog.LogConverter
-	var customParser *formats.Parser
+	formatDetector, logConverter, customParser := newFormatComponents(cfg.Format, configDir)
+
+	multilineConfig := resolveMultilineConfig(multiline.Config{
+		Preset:              cfg.Multiline,
+		StartPattern:        cfg.MultilineStart,
+		ContinuationPattern: cfg.MultilineContinue,
+		Timeout:             cfg.MultilineTimeout,
+	}, customParser)
+	if err := validateMultilineConfig(multilineConfig); err != nil {
+		return err
+	}
 
-	if cfg.Format != "" {
-		// Check if it's a built-in format
-		switch strings.ToLower(cfg.Format) {
-		case "otlp", "json", "text":
-			// Built-in format
-			formatDetector = otlplog.NewFormatDetectorWithFormat(cfg.Format)
-			logConverter = otlplog.NewLogConverter()
-		default:
-			// Try to load custom format
-			format, err := formats.LoadFormatByName(cfg.Format, configDir)
-			if err != nil {
-				log.Printf("Warning: Failed to load custom format '%s': %v (using auto-detect)", cfg.Format, err)
-				formatDetector = otlplog.NewFormatDetector()
-				logConverter = otlplog.NewLogConverter()
-			} else {
-				// Create parser for the custom format
-				customParser, err = formats.NewParser(format)
-				if err != nil {
-					log.Printf("Warning: Failed to create parser for format '%s': %v (using auto-detect)", cfg.Format, err)
-					formatDetector = otlplog.NewFormatDetector()
-					logConverter = otlplog.NewLogConverter()
-				} else {
-					formatDetector = otlplog.NewFormatDetectorWithFormat(cfg.Format)
-					logConverter = otlplog.NewLogConverterWithFormat(cfg.Format, customParser)
-					log.Printf("Using custom format: %s", cfg.Format)
-				}
-			}
+	// File start position, with checkpoints of previous runs when resuming
+	from, err := filereader.ParseFrom(cfg.From)
+	if err != nil {
+		return err
+	}
+	fileOptions := filereader.Options{
+		Follow:    cfg.Follow,
+		Multiline: multilineConfig,
+		From:      from,
+		TailLines: cfg.TailLines,
+	}
+	if from == filereader.FromCheckpoint {
+		fileOptions.Checkpoints = filereader.NewCheckpointStore(filepath.Join(configDir, "state"))
+	}
+
+	// Victoria Logs history query
+	vmlogsHistory, err := resolveVmlogsHistory()
+	if err != nil {
+		return err
+	}
+
+	// Replay pacing by original timestamps
+	var replay *replayClock
+	if cfg.Replay != "" {
+		if replay, err = newReplayClock(cfg.Replay); err != nil {
+			return err
 		}
-	} else {
-		// Auto-detect format
-		formatDetector = otlplog.NewFormatDetector()
-		logConverter = otlplog.NewLogConverter()
 	}
 
 	textAnalyzer := analyzer.NewTextAnalyzerWithStopWords(cfg.StopWords)
@@ -94,18 +105,59 @@ func runApp(cmd *cobra.Command, args []string) error {
 	if versionChecker != nil {
 		dashboard.SetVersionChecker(versionChecker)
 	}
+	dashboard.SetNewPatternBaseline(cfg.NewPatternBaseline)
+	dashboard.SetAnomalyThreshold(cfg.AnomalyThreshold)
+	dashboard.SetCorrelationKeys(cfg.CorrelationKeys)
+	if replay != nil {
+		dashboard.SetReplay(replay.status())
+	}
+
+	// Files read at once and queried history arrive within seconds, chart them by
+	// their original timestamps. Followed and replayed input arrives over time as
+	// it happened.
+	fileBackfill := len(cfg.Files) > 0 && !cfg.Follow
+	vmlogsBackfill := !vmlogsHistory.Start.IsZero() && !vmlogsHistory.Follow
+	if (fileBackfill || vmlogsBackfill) && replay == nil {
+		dashboard.SetEventTimeAxis(true)
+	}
+
+	// Load templates learned in previous runs (a restored session below takes precedence)
+	var patternsStatePath string
+	if cfg.PatternsState != "" {
+		patternsStatePath = resolvePatternsStatePath(cfg.PatternsState, configDir)
+		if err := dashboard.LoadPatternsState(patternsStatePath); err != nil {
+			log.Printf("Warning: Failed to load patterns state '%s': %v (starting with no known patterns)", patternsStatePath, err)
+		} else if known := dashboard.PatternsBaselineSize(); known > 0 {
+			log.Printf("Loaded %d known log patterns from %s", known, patternsStatePath)
+		}
+	}
+
+	// Restore a previously saved session before any new input arrives
+	if cfg.LoadSession != "" {
+		session, err := tui.LoadSession(cfg.LoadSession)
+		if err != nil {
+			return fmt.Errorf("failed to load session '%s': %w", cfg.LoadSession, err)
+		}
+		dashboard.RestoreSession(session)
+		freqMemory.Restore(session.Frequency)
+		log.Printf("Restored session from %s (saved %s, %d log entries)", cfg.LoadSession, session.SavedAt.Format(time.RFC3339), len(session.LogEntries))
+	}
 
 	tuiModel := &simpleTuiModel{
-		formatDetector: formatDetector,
-		logConverter:   logConverter,
-		customParser:   customParser,
-		textAnalyzer:   textAnalyzer,
-		otlpAnalyzer:   otlpAnalyzer,
-		freqMemory:     freqMemory,
-		dashboard:      dashboard,
-		updateInterval: cfg.UpdateInterval,
-		testMode:       cfg.TestMode,
-		versionChecker: versionChecker,
+		formatDetector:  formatDetector,
+		logConverter:    logConverter,
+		customParser:    customParser,
+		multilineConfig: multilineConfig,
+		fileOptions:     fileOptions,
+		vmlogsHistory:   vmlogsHistory,
+		replay:          replay,
+		textAnalyzer:    textAnalyzer,
+		otlpAnalyzer:    otlpAnalyzer,
+		freqMemory:      freqMemory,
+		dashboard:       dashboard,
+		updateInterval:  cfg.UpdateInterval,
+		testMode:        cfg.TestMode,
+		versionChecker:  versionChecker,
 	}
 
 	var p *tea.Program
@@ -131,15 +183,203 @@ func runApp(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error running TUI: %w", err)
 	}
 
+	// Let the file reader finish, which writes the final checkpoints
+	if tuiModel.fileReader != nil {
+		tuiModel.fileReader.Stop()
+		tuiModel.fileReader.Wait()
+	}
+
+	if patternsStatePath != "" {
+		if err := tuiModel.dashboard.SavePatternsState(patternsStatePath); err != nil {
+			log.Printf("Warning: Failed to save patterns state '%s': %v", patternsStatePath, err)
+		}
+	}
+
+	if cfg.SaveSession != "" {
+		path := resolveSessionPath(cfg.SaveSession, configDir)
+		session := tuiModel.dashboard.Session()
+		session.Frequency = freqMemory.GetSnapshot()
+		if err := tui.SaveSession(path, session); err != nil {
+			return fmt.Errorf("failed to save session: %w", err)
+		}
+		fmt.Printf("Session saved to %s\n", path)
+	}
+
 	return nil
 }
 
+// autoSessionPath is the --save-session value used when the flag is given without a path
+const autoSessionPath = "auto"
+
+// resolveSessionPath expands the automatic session path into a timestamped file
+func resolveSessionPath(path, configDir string) string {
+	if path != autoSessionPath {
+		return path
+	}
+	return filepath.Join(configDir, "sessions", fmt.Sprintf("session-%s.gonzo", time.Now().Format("20060102-150405")))
+}
+
+// resolvePatternsStatePath turns a --patterns-state name into a file under the
+// config directory; values that look like paths are used as given
+func resolvePatternsStatePath(name, configDir string) string {
+	if strings.ContainsRune(name, os.PathSeparator) || strings.HasSuffix(name, ".json") {
+		return name
+	}
+	return filepath.Join(configDir, "patterns", name+".json")
+}
+
+// newFormatComponents creates the format detector, converter and optional custom parser
+// for the given format name (empty means auto-detect)
+func newFormatComponents(formatName, configDir string) (*otlplog.FormatDetector, *otlplog.LogConverter, *formats.Parser) {
+	var formatDetector *otlplog.FormatDetector
+	var logConverter *otlplog.LogConverter
+	var customParser *formats.Parser
+
+	if formatName != "" {
+		// Check if it's a built-in format
+		switch strings.ToLower(formatName) {
+		case "otlp", "json", "text", "logfmt":
+			// Built-in format
+			formatDetector = otlplog.NewFormatDetectorWithFormat(formatName)
+			logConverter = otlplog.NewLogConverter()
+		default:
+			// Try to load custom format
+			format, err := formats.LoadFormatByName(formatName, configDir)
+			if err != nil {
+				log.Printf("Warning: Failed to load custom format '%s': %v (using auto-detect)", formatName, err)
+				formatDetector = otlplog.NewFormatDetector()
+				logConverter = otlplog.NewLogConverter()
+			} else {
+				// Create parser for the custom format
+				customParser, err = formats.NewParser(format)
+				if err != nil {
+					log.Printf("Warning: Failed to create parser for format '%s': %v (using auto-detect)", formatName, err)
+					formatDetector = otlplog.NewFormatDetector()
+					logConverter = otlplog.NewLogConverter()
+				} else {
+					formatDetector = otlplog.NewFormatDetectorWithFormat(formatName)
+					logConverter = otlplog.NewLogConverterWithFormat(formatName, customParser)
+					log.Printf("Using custom format: %s", formatName)
+				}
+			}
+		}
+	} else {
+		// Auto-detect format
+		formatDetector = otlplog.NewFormatDetector()
+		logConverter = otlplog.NewLogConverter()
+	}
+
+	return formatDetector, logConverter, customParser
+}
+
+// resolveMultilineConfig returns the multiline settings from the command line,
+// falling back to the custom format's multiline section
+func resolveMultilineConfig(config multiline.Config, customParser *formats.Parser) multiline.Config {
+	if customParser != nil && !config.Enabled() {
+		config = config.Merge(customParser.Format.Multiline)
+	}
+	return config
+}
+
+// validateMultilineConfig checks the multiline patterns once on startup; every
+// source then creates its own assembler from the config
+func validateMultilineConfig(config multiline.Config) error {
+	if !config.Enabled() {
+		return nil
+	}
+	if _, err := multiline.NewAssembler(config); err != nil {
+		return fmt.Errorf("invalid multiline configuration: %w", err)
+	}
+	return nil
+}
+
+// newK8sReceiver creates the Kubernetes receiver from the --k8s-* settings. Multi-line
+// records are assembled per container, so the receiver gets the config rather than
+// sharing the global assembler.
+func newK8sReceiver(multilineConfig multiline.Config) (*k8s.Receiver, error) {
+	config, err := k8s.LoadConfig(cfg.Kubeconfig, cfg.K8sContext)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := cfg.K8sNamespace
+	if namespace == "" {
+		namespace = config.Namespace
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	if cfg.K8sAllNamespaces {
+		namespace = ""
+	}
+
+	return k8s.NewReceiver(k8s.NewClient(config), k8s.ReceiverOptions{
+		Namespace: namespace,
+		Selector:  cfg.K8sSelector,
+		Container: cfg.K8sContainer,
+		TailLines: cfg.K8sTail,
+		Multiline: multilineConfig,
+	}), nil
+}
+
+// resolveVmlogsHistory returns the Victoria Logs history query asked for with
+// --vmlogs-start, --vmlogs-end, --vmlogs-limit and --vmlogs-follow
+func resolveVmlogsHistory() (vmlogs.History, error) {
+	now := time.Now()
+	start, err := vmlogs.ParseTime(cfg.VmlogsStart, now)
+	if err != nil {
+		return vmlogs.History{}, fmt.Errorf("invalid --vmlogs-start: %w", err)
+	}
+	end, err := vmlogs.ParseTime(cfg.VmlogsEnd, now)
+	if err != nil {
+		return vmlogs.History{}, fmt.Errorf("invalid --vmlogs-end: %w", err)
+	}
+
+	switch {
+	case start.IsZero() && (!end.IsZero() || cfg.VmlogsLimit > 0 || cfg.VmlogsFollow):
+		return vmlogs.History{}, fmt.Errorf("--vmlogs-end, --vmlogs-limit and --vmlogs-follow require --vmlogs-start")
+	case !end.IsZero() && !end.After(start):
+		return vmlogs.History{}, fmt.Errorf("--vmlogs-end must be after --vmlogs-start")
+	case !end.IsZero() && cfg.VmlogsFollow:
+		// Tailing starts now, the logs between the end and now would be missing
+		return vmlogs.History{}, fmt.Errorf("--vmlogs-follow cannot be combined with --vmlogs-end")
+	}
+
+	return vmlogs.History{
+		Start:  start,
+		End:    end,
+		Limit:  cfg.VmlogsLimit,
+		Follow: cfg.VmlogsFollow,
+	}, nil
+}
+
+// inputLine is a line read from an input source, with optional attributes
+// describing where it came from (added to every log entry parsed from it)
+type inputLine struct {
+	text       string
+	source     string // Input source name, stored as the gonzo.source attribute
+	attributes map[string]string
+	record     *otlpreceiver.Record // Record of the OTLP receiver, processed instead of text
+	ended      bool                 // Sent once after the last line of a source
+}
+
+// Input source names (values of the gonzo.source attribute)
+const (
+	sourceStdin  = "stdin"
+	sourceFile   = "file"
+	sourceOTLP   = "otlp"
+	sourceVmlogs = "vmlogs"
+	sourceK8s    = "k8s"
+	sourceSyslog = "syslog"
+)
+
 // Message types for bubbletea
 type (
-	logLineMsg  string
-	snapshotMsg *memory.FrequencySnapshot
-	finishedMsg struct{}
-	tickMsg     struct {
+	logLineMsg   inputLine
+	snapshotMsg  *memory.FrequencySnapshot
+	finishedMsg  struct{}
+	fileEventMsg filereader.FileEvent
+	tickMsg      struct {
 		time     time.Time
 		sequence int
 	}
@@ -147,18 +387,21 @@ type (
 
 // simpleTuiModel is the main TUI model that handles everything internally
 type simpleTuiModel struct {
-	formatDetector *otlplog.FormatDetector
-	logConverter   *otlplog.LogConverter
-	customParser   *formats.Parser
-	textAnalyzer   *analyzer.TextAnalyzer
-	otlpAnalyzer   *analyzer.OTLPAnalyzer
-	freqMemory     *memory.FrequencyMemory
-	dashboard      *tui.DashboardModel
-	updateInterval time.Duration
-	testMode       bool
-	ctx            context.Context
-	cancelFunc     context.CancelFunc
-	versionChecker *versioncheck.Checker
+	formatDetector  *otlplog.FormatDetector
+	logConverter    *otlplog.LogConverter
+	customParser    *formats.Parser
+	multilineConfig multiline.Config // Multi-line record assembly (stack traces), applied per source
+	fileOptions     filereader.Options
+	vmlogsHistory   vmlogs.History
+	textAnalyzer    *analyzer.TextAnalyzer
+	otlpAnalyzer    *analyzer.OTLPAnalyzer
+	freqMemory      *memory.FrequencyMemory
+	dashboard       *tui.DashboardModel
+	updateInterval  time.Duration
+	testMode        bool
+	ctx             context.Context
+	cancelFunc      context.CancelFunc
+	versionChecker  *versioncheck.Checker
 
 	// Internal state
 	finished       bool
@@ -170,21 +413,46 @@ type simpleTuiModel struct {
 
 	// File reading support
 	fileReader   *filereader.FileReader // File reader for file input mode
-	inputChan    chan string            // Unified input channel (from stdin or files)
+	inputChan    chan inputLine         // Unified input channel (from all sources)
+	sources      sync.WaitGroup         // Active input sources feeding inputChan
 	hasFileInput bool                   // Whether we're reading from files
 
+	// Replay support (nil unless --replay is set)
+	replay               *replayClock
+	replayPending        *inputLine // Line waiting until the replay clock reaches it
+	replayPendingTime    time.Time  // Original time of the pending line
+	replayPendingHasTime bool       // Whether the pending line has a timestamp
+	replaySequence       int        // Invalidates replay timers scheduled before a pause or seek
+
 	// OTLP receiver support
 	otlpReceiver *otlpreceiver.Receiver // OTLP receiver for network input
 	hasOTLPInput bool                   // Whether we're receiving OTLP data
 
+	// Resource of the last OTLP record and its attributes, reused by the records of the same request
+	otlpResource           *resourcepb.Resource
+	otlpResourceAttributes map[string]string
+
+	// Syslog receiver support
+	syslogReceiver *syslogreceiver.Receiver // Syslog receiver for network input
+	hasSyslogInput bool                     // Whether we're receiving syslog messages
+
 	// Victoria Logs receiver support
 	vmlogsReceiver *vmlogs.Receiver // Victoria Logs receiver for streaming logs
 	hasVmlogsInput bool             // Whether we're receiving Victoria Logs data
 
-	// JSON accumulation for multi-line OTLP support
-	jsonBuffer   strings.Builder // Buffer for accumulating multi-line JSON
-	jsonDepth    int             // Track JSON object/array nesting depth
-	inJsonObject bool            // Whether we're currently accumulating a JSON object
+	// Kubernetes receiver support
+	k8sReceiver *k8s.Receiver // Kubernetes receiver following pod logs
+	hasK8sInput bool          // Whether we're receiving Kubernetes pod logs
+
+	// JSON accumulation for multi-line OTLP support, per input stream
+	jsonAccumulators map[jsonStream]*jsonAccumulator
+
+	// Attributes and stream of the input line currently being processed (see processInputLine)
+	lineAttributes map[string]string
+	lineStream     jsonStream
+
+	// Headless report support (dashboard is nil in report mode)
+	reportBuilder *output.ReportBuilder
 }
 
 // Init initializes the TUI model
@@ -195,77 +463,113 @@ func (m *simpleTuiModel) Init() tea.Cmd {
 	// Initialize frequency reset timer
 	m.lastFreqReset = time.Now()
 
+	// All configured sources feed one input channel, so e.g. OTLP and files can be combined
+	m.inputChan = make(chan inputLine, 100)
+
 	// Check if Victoria Logs receiver is enabled
 	if cfg.VmlogsURL != "" {
-		// Victoria Logs input mode
-		m.hasVmlogsInput = true
-		m.inputChan = make(chan string, 100)
-
 		// Create and start Victoria Logs receiver
 		params := make(map[string]string)
 		// Add any default parameters here if needed
 		m.vmlogsReceiver = vmlogs.NewReceiver(cfg.VmlogsURL, cfg.VmlogsUser, cfg.VmlogsPassword, cfg.VmlogsQuery, params)
+		m.vmlogsReceiver.SetHistory(m.vmlogsHistory)
 		if err := m.vmlogsReceiver.Start(); err != nil {
 			log.Printf("Error starting Victoria Logs receiver: %v", err)
-			// Fall back to other input methods if Victoria Logs fails
-			m.hasVmlogsInput = false
 		} else {
 			// Start reading from Victoria Logs receiver in the background
-			go m.readVmlogsAsync()
+			m.hasVmlogsInput = true
+			go m.readVmlogsAsync(m.lineSink(sourceVmlogs))
 		}
 	}
 
-	// Check if OTLP receiver is enabled (only if Victoria Logs is not enabled)
-	if !m.hasVmlogsInput && cfg.OTLPEnabled {
-		// OTLP input mode
-		m.hasOTLPInput = true
-		m.inputChan = make(chan string, 100)
+	// Check if Kubernetes receiver is enabled
+	if cfg.K8s {
+		// Create and start Kubernetes receiver
+		var err error
+		m.k8sReceiver, err = newK8sReceiver(m.multilineConfig)
+		if err == nil {
+			err = m.k8sReceiver.Start()
+		}
+		if err != nil {
+			log.Printf("Error starting Kubernetes receiver: %v", err)
+		} else {
+			// Start reading from Kubernetes receiver in the background
+			m.hasK8sInput = true
+			go m.readK8sAsync(m.addSource(sourceK8s))
+		}
+	}
 
+	// Check if OTLP receiver is enabled
+	if cfg.OTLPEnabled {
 		// Create and start OTLP receiver
-		m.otlpReceiver = otlpreceiver.NewReceiver(cfg.OTLPGRPCPort, cfg.OTLPHTTPPort)
+		m.otlpReceiver = otlpreceiver.NewReceiver(otlpreceiver.Options{
+			GRPCPort:        cfg.OTLPGRPCPort,
+			HTTPPort:        cfg.OTLPHTTPPort,
+			BindAddress:     cfg.OTLPBindAddress,
+			TLSCertFile:     cfg.OTLPTLSCert,
+			TLSKeyFile:      cfg.OTLPTLSKey,
+			TLSClientCAFile: cfg.OTLPTLSClientCA,
+			AuthToken:       cfg.OTLPAuthToken,
+			AuthUser:        cfg.OTLPAuthUser,
+			AuthPassword:    cfg.OTLPAuthPassword,
+			MaxPayloadSize:  cfg.OTLPMaxPayload * 1024 * 1024,
+		})
 		if err := m.otlpReceiver.Start(); err != nil {
 			log.Printf("Error starting OTLP receiver: %v", err)
-			// Fall back to other input methods if OTLP fails
-			m.hasOTLPInput = false
 		} else {
 			// Start reading from OTLP receiver in the background
-			go m.readOTLPAsync()
+			m.hasOTLPInput = true
+			go m.readOTLPAsync(m.addSource(sourceOTLP))
+			m.dashboard.SetTraceLookup(traceLookup(m.otlpReceiver.Spans()))
 		}
 	}
 
-	// Check if we have file inputs specified (only if Victoria Logs and OTLP are not enabled)
-	if !m.hasVmlogsInput && !m.hasOTLPInput && len(cfg.Files) > 0 {
-		// File input mode
-		m.hasFileInput = true
-		m.inputChan = make(chan string, 100)
+	// Check if syslog receiver is enabled
+	if cfg.SyslogEnabled {
+		// Create and start syslog receiver
+		m.syslogReceiver = syslogreceiver.NewReceiver(syslogreceiver.Options{
+			UDPPort:     cfg.SyslogUDPPort,
+			TCPPort:     cfg.SyslogTCPPort,
+			TLSCertFile: cfg.SyslogTLSCert,
+			TLSKeyFile:  cfg.SyslogTLSKey,
+		})
+		if err := m.syslogReceiver.Start(); err != nil {
+			log.Printf("Error starting syslog receiver: %v", err)
+		} else {
+			// Start reading from syslog receiver in the background
+			m.hasSyslogInput = true
+			go m.readSyslogAsync(m.rawSink(sourceSyslog))
+		}
+	}
 
+	// Check if we have file inputs specified
+	if len(cfg.Files) > 0 {
 		// Create file reader
 		var err error
-		m.fileReader, err = filereader.New(cfg.Files, cfg.Follow)
+		m.fileReader, err = filereader.New(cfg.Files, m.fileOptions)
 		if err != nil {
 			log.Printf("Error setting up file reader: %v", err)
-			// Fall back to stdin if file reading fails
-			m.hasFileInput = false
 		} else {
-			// Start file reading in the background
-			go m.readFilesAsync()
+			// Start file reading in the background. Multi-line records are
+			// assembled by the file reader, separately for every file.
+			m.hasFileInput = true
+			go m.readFilesAsync(m.addSource(sourceFile))
 		}
 	}
 
-	// If no Victoria Logs, no OTLP, no file input or file input failed, check stdin
-	if !m.hasVmlogsInput && !m.hasOTLPInput && !m.hasFileInput {
-		// Check if stdin has data available (not a terminal)
-		stat, _ := os.Stdin.Stat()
-		if (stat.Mode() & os.ModeCharDevice) == 0 {
-			// stdin is a pipe or file, we have data
-			m.hasStdinData = true
-			m.inputChan = make(chan string, 100)
+	// Check if stdin has data available (not a terminal)
+	stat, _ := os.Stdin.Stat()
+	if (stat.Mode() & os.ModeCharDevice) == 0 {
+		// stdin is a pipe or file, we have data
+		m.hasStdinData = true
 
-			// Start goroutine to read stdin without blocking
-			go m.readStdinAsync()
-		}
+		// Start goroutine to read stdin without blocking
+		go m.readStdinAsync(m.lineSink(sourceStdin))
 	}
 
+	// Close the input channel once every source has finished
+	m.closeInputWhenDone()
+
 	// Start the dashboard
 	dashboardCmd := m.dashboard.Init()
 
@@ -274,16 +578,132 @@ func (m *simpleTuiModel) Init() tea.Cmd {
 	cmds = append(cmds, m.periodicUpdate())
 
 	// Start checking for input data if we have any input source
-	if m.hasStdinData || m.hasFileInput || m.hasOTLPInput || m.hasVmlogsInput {
+	if m.hasInput() {
 		cmds = append(cmds, m.checkInputChannel())
 	}
 
+	// Report files picked up or dropped while following
+	if m.hasFileInput && cfg.Follow {
+		cmds = append(cmds, m.waitForFileEvent())
+	}
+
 	return tea.Batch(cmds...)
 }
 
+// hasInput reports whether any input source is active
+func (m *simpleTuiModel) hasInput() bool {
+	return m.hasStdinData || m.hasFileInput || m.hasOTLPInput || m.hasSyslogInput || m.hasVmlogsInput || m.hasK8sInput
+}
+
+// addSource registers an input source and returns the channel it writes to. Lines
+// are tagged with the source name on their way to the input channel, followed by
+// an ended line once the source closes its channel.
+func (m *simpleTuiModel) addSource(source string) chan<- inputLine {
+	lines := make(chan inputLine, 100)
+	m.sources.Add(1)
+	go func() {
+		defer m.sources.Done()
+		for line := range lines {
+			line.source = source
+			select {
+			case m.inputChan <- line:
+			case <-m.ctx.Done():
+				return
+			}
+		}
+		select {
+		case m.inputChan <- inputLine{source: source, ended: true}:
+		case <-m.ctx.Done():
+		}
+	}()
+	return lines
+}
+
+// closeInputWhenDone closes the input channel after all registered sources finished
+func (m *simpleTuiModel) closeInputWhenDone() {
+	go func() {
+		m.sources.Wait()
+		close(m.inputChan)
+	}()
+}
+
+// lineSink returns the channel a line reader for source should write to. When
+// multiline assembly is enabled, lines pass through an assembler of their own so
+// that stack traces of different sources never mix. Multi-line JSON is assembled
+// later, per input stream (see tryAccumulateJSON).
+func (m *simpleTuiModel) lineSink(source string) chan<- string {
+	if !m.multilineConfig.Enabled() {
+		return m.rawSink(source)
+	}
+	assembler, err := multiline.NewAssembler(m.multilineConfig)
+	if err != nil {
+		// The config was validated on startup, so this cannot happen
+		return m.rawSink(source)
+	}
+
+	rawChan := make(chan string, 100)
+	records := make(chan string, 100)
+	go assembler.Run(m.ctx, rawChan, records)
+	go m.forwardLines(records, m.addSource(source))
+	return rawChan
+}
+
+// rawSink returns a channel whose lines reach the input channel unchanged
+func (m *simpleTuiModel) rawSink(source string) chan<- string {
+	lines := make(chan string, 100)
+	go m.forwardLines(lines, m.addSource(source))
+	return lines
+}
+
+// forwardLines wraps plain lines for a source channel and closes it once lines is closed
+func (m *simpleTuiModel) forwardLines(lines <-chan string, out chan<- inputLine) {
+	defer close(out)
+
+	for line := range lines {
+		select {
+		case out <- inputLine{text: line}:
+		case <-m.ctx.Done():
+			return
+		}
+	}
+}
+
+// readK8sAsync reads from the Kubernetes receiver
+func (m *simpleTuiModel) readK8sAsync(out chan<- inputLine) {
+	defer close(out)
+
+	if m.k8sReceiver == nil {
+		return
+	}
+
+	// Get the channel from Kubernetes receiver
+	k8sLineChan := m.k8sReceiver.GetLineChan()
+
+	// Forward lines with their pod attributes to input channel
+	for {
+		select {
+		case <-m.ctx.Done():
+			m.k8sReceiver.Stop()
+			return
+		case line, ok := <-k8sLineChan:
+			if !ok {
+				// Kubernetes receiver finished
+				return
+			}
+			if line.Text != "" {
+				select {
+				case out <- inputLine{text: line.Text, attributes: line.Attributes}:
+				case <-m.ctx.Done():
+					return
+				}
+			}
+		}
+	}
+}
+
 // readVmlogsAsync reads from the Victoria Logs receiver
-func (m *simpleTuiModel) readVmlogsAsync() {
-	defer close(m.inputChan)
+func (m *simpleTuiModel) readVmlogsAsync(out chan<- string) {
+	defer close(out)
 
 	if m.vmlogsReceiver == nil {
 		return
@@ -305,7 +725,7 @@ func (m *simpleTuiModel) readVmlogsAsync() {
 			}
 			if line != "" {
 				select {
-				case m.inputChan <- line:
+				case out <- line:
 				case <-m.ctx.Done():
 					return
 				}
@@ -315,30 +735,30 @@ func (m *simpleTuiModel) readVmlogsAsync() {
 }
 
 // readOTLPAsync reads from the OTLP receiver
-func (m *simpleTuiModel) readOTLPAsync() {
-	defer close(m.inputChan)
+func (m *simpleTuiModel) readOTLPAsync(out chan<- inputLine) {
+	defer close(out)
 
 	if m.otlpReceiver == nil {
 		return
 	}
 
 	// Get the channel from OTLP receiver
-	otlpLineChan := m.otlpReceiver.GetLineChan()
+	otlpRecordChan := m.otlpReceiver.GetRecordChan()
 
-	// Forward lines from OTLP receiver to input channel
+	// Forward records from OTLP receiver to input channel
 	for {
 		select {
 		case <-m.ctx.Done():
 			m.otlpReceiver.Stop()
 			return
-		case line, ok := <-otlpLineChan:
+		case record, ok := <-otlpRecordChan:
 			if !ok {
 				// OTLP receiver finished
 				return
 			}
-			if line != "" {
+			if record.Log != nil {
 				select {
-				case m.inputChan <- line:
+				case out <- inputLine{record: &record}:
 				case <-m.ctx.Done():
 					return
 				}
@@ -347,9 +767,41 @@ func (m *simpleTuiModel) readOTLPAsync() {
 	}
 }
 
-// readFilesAsync reads from files using the FileReader
-func (m *simpleTuiModel) readFilesAsync() {
-	defer close(m.inputChan)
+// readSyslogAsync reads from the syslog receiver
+func (m *simpleTuiModel) readSyslogAsync(out chan<- string) {
+	defer close(out)
+
+	if m.syslogReceiver == nil {
+		return
+	}
+
+	// Get the channel from syslog receiver
+	syslogLineChan := m.syslogReceiver.GetLineChan()
+
+	// Forward messages (converted to OTLP records) to input channel
+	for {
+		select {
+		case <-m.ctx.Done():
+			m.syslogReceiver.Stop()
+			return
+		case line, ok := <-syslogLineChan:
+			if !ok {
+				// Syslog receiver finished
+				return
+			}
+			select {
+			case out <- line:
+			case <-m.ctx.Done():
+				m.syslogReceiver.Stop()
+				return
+			}
+		}
+	}
+}
+
+// readFilesAsync reads from files using the FileReader, tagging every line with its file
+func (m *simpleTuiModel) readFilesAsync(out chan<- inputLine) {
+	defer close(out)
 
 	if m.fileReader == nil {
 		return
@@ -369,9 +821,9 @@ func (m *simpleTuiModel) readFilesAsync() {
 				// File reader finished
 				return
 			}
-			if line != "" {
+			if line.Text != "" {
 				select {
-				case m.inputChan <- line:
+				case out <- inputLine{text: line.Text, attributes: fileAttributes(line)}:
 				case <-m.ctx.Done():
 					return
 				}
@@ -380,9 +832,30 @@ func (m *simpleTuiModel) readFilesAsync() {
 	}
 }
 
+// fileAttributes returns the origin attributes of a line read from a file
+func fileAttributes(line filereader.Line) map[string]string {
+	return map[string]string{
+		tui.FilePathAttribute:   line.Path,
+		tui.FileNameAttribute:   filepath.Base(line.Path),
+		tui.FileOffsetAttribute: strconv.FormatInt(line.Offset, 10),
+	}
+}
+
+// traceLookup returns a lookup of the spans in the OTLP receiver's span index for the dashboard
+func traceLookup(index *otlpreceiver.SpanIndex) func(traceID string) []tui.TraceSpan {
+	return func(traceID string) []tui.TraceSpan {
+		spans := index.Trace(traceID)
+		traceSpans := make([]tui.TraceSpan, len(spans))
+		for i, span := range spans {
+			traceSpans[i] = tui.TraceSpan(span)
+		}
+		return traceSpans
+	}
+}
+
 // readStdinAsync reads from stdin in a goroutine without blocking
-func (m *simpleTuiModel) readStdinAsync() {
-	defer close(m.inputChan)
+func (m *simpleTuiModel) readStdinAsync(out chan<- string) {
+	defer close(out)
 
 	scanner := bufio.NewScanner(os.Stdin)
 
@@ -407,13 +880,13 @@ func (m *simpleTuiModel) readStdinAsync() {
 		case hasLine := <-scanChan:
 			if !hasLine {
 				// EOF or error - exit gracefully
-				break
+				return
 			}
 
 			line := scanner.Text()
 			if line != "" {
 				select {
-				case m.inputChan <- line:
+				case out <- line:
 				case <-m.ctx.Done():
 					return
 				}
@@ -431,7 +904,7 @@ func (m *simpleTuiModel) checkInputChannel() tea.Cmd {
 				// Channel closed, input is done
 				return finishedMsg{}
 			}
-			if line != "" {
+			if line.text != "" || line.record != nil || line.ended {
 				return logLineMsg(line)
 			}
 			// Empty line, continue checking
@@ -444,6 +917,18 @@ func (m *simpleTuiModel) checkInputChannel() tea.Cmd {
 	}
 }
 
+// waitForFileEvent waits for the next file added to or removed from follow mode
+func (m *simpleTuiModel) waitForFileEvent() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case event := <-m.fileReader.Events():
+			return fileEventMsg(event)
+		case <-m.ctx.Done():
+			return nil
+		}
+	}
+}
+
 // Update handles messages and updates the model
 func (m *simpleTuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
@@ -492,13 +977,38 @@ func (m *simpleTuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 
 	case logLineMsg:
-		m.processLogLine(string(msg))
+		if m.replay != nil {
+			// The line is processed once the replay clock reaches its timestamp
+			cmds = append(cmds, m.replayLine(inputLine(msg)))
+			break
+		}
+		m.processInputLine(inputLine(msg))
 
 		// Continue checking for more data if we have input sources
-		if (m.hasStdinData || m.hasFileInput || m.hasOTLPInput || m.hasVmlogsInput) && !m.finished {
+		if m.hasInput() && !m.finished {
 			cmds = append(cmds, m.checkInputChannel())
 		}
 
+	case replayReadyMsg:
+		// Ignore timers scheduled before a pause or seek
+		if msg.sequence == m.replaySequence {
+			cmds = append(cmds, m.replayNext())
+		}
+
+	case tui.ReplayControlMsg:
+		if m.replay != nil {
+			cmds = append(cmds, m.controlReplay(msg))
+		}
+
+	case fileEventMsg:
+		notice := "Following new file " + msg.Path
+		if msg.Removed {
+			notice = "Stopped following deleted file " + msg.Path
+		}
+		newDashboard, cmd := m.dashboard.Update(tui.StatusNoticeMsg(notice))
+		m.dashboard = newDashboard.(*tui.DashboardModel)
+		cmds = append(cmds, cmd, m.waitForFileEvent())
+
 	case snapshotMsg:
 		// Send snapshot to dashboard
 		updateMsg := tui.UpdateMsg{
@@ -561,6 +1071,11 @@ func (m *simpleTuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Always reset log count for counts chart tracking
 		m.logCount = 0
 
+		// Keep the replay position in the status line current
+		if m.replay != nil {
+			cmds = append(cmds, m.updateReplayStatus())
+		}
+
 		// Always schedule next update to keep dashboard refreshing
 		cmds = append(cmds, m.periodicUpdate())
 
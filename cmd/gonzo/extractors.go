@@ -1,15 +1,19 @@
 package main
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/control-theory/gonzo/internal/otlpreceiver"
 	"github.com/control-theory/gonzo/internal/tui"
 
 	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
 	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
 )
 
 // extractLogEntryFromOTLPBatch extracts a LogEntry from OTLP batch data (DEPRECATED - only gets first log)
@@ -29,14 +33,7 @@ func extractAllLogEntriesFromOTLPBatch(logsData *logspb.LogsData) []*tui.LogEntr
 	// Process all resource logs
 	for _, resourceLog := range logsData.ResourceLogs {
 		// Extract resource attributes first
-		resourceAttributes := make(map[string]string)
-		if resourceLog.Resource != nil {
-			for _, attr := range resourceLog.Resource.Attributes {
-				if attr.Key != "" && attr.Value != nil {
-					resourceAttributes[attr.Key] = extractStringFromAnyValue(attr.Value)
-				}
-			}
-		}
+		resourceAttributes := extractResourceAttributes(resourceLog.Resource)
 
 		// Process all scope logs within each resource
 		for _, scopeLog := range resourceLog.ScopeLogs {
@@ -59,13 +56,7 @@ func extractLogEntryFromOTLPRecordWithResource(record *logspb.LogRecord, resourc
 	receiveTime := time.Now()
 
 	// Extract original timestamp if available
-	// Prefer TimeUnixNano, fallback to ObservedTimeUnixNano
-	origTimestamp := time.Time{}
-	if record.TimeUnixNano > 0 {
-		origTimestamp = time.Unix(0, int64(record.TimeUnixNano))
-	} else if record.ObservedTimeUnixNano > 0 {
-		origTimestamp = time.Unix(0, int64(record.ObservedTimeUnixNano))
-	}
+	origTimestamp := recordTimestamp(record)
 
 	// Extract severity with proper fallback priority:
 	// 1. Use SeverityText if present
@@ -113,6 +104,55 @@ func extractLogEntryFromOTLPRecordWithResource(record *logspb.LogRecord, resourc
 	}
 }
 
+// recordTimestamp returns the original time of an OTLP record, preferring
+// TimeUnixNano over ObservedTimeUnixNano. It is zero if neither is set.
+func recordTimestamp(record *logspb.LogRecord) time.Time {
+	if record.TimeUnixNano > 0 {
+		return time.Unix(0, int64(record.TimeUnixNano))
+	}
+	if record.ObservedTimeUnixNano > 0 {
+		return time.Unix(0, int64(record.ObservedTimeUnixNano))
+	}
+	return time.Time{}
+}
+
+// extractLogEntryFromReceivedRecord extracts a LogEntry from a record of the OTLP
+// receiver, adding its instrumentation scope and trace context as attributes
+func extractLogEntryFromReceivedRecord(record otlpreceiver.Record, resourceAttributes map[string]string) *tui.LogEntry {
+	entry := extractLogEntryFromOTLPRecordWithResource(record.Log, resourceAttributes)
+
+	if record.Scope != nil {
+		if record.Scope.Name != "" {
+			entry.Attributes[tui.ScopeNameAttribute] = record.Scope.Name
+		}
+		if record.Scope.Version != "" {
+			entry.Attributes[tui.ScopeVersionAttribute] = record.Scope.Version
+		}
+	}
+	if len(record.Log.TraceId) > 0 {
+		entry.Attributes[tui.TraceIDAttribute] = hex.EncodeToString(record.Log.TraceId)
+	}
+	if len(record.Log.SpanId) > 0 {
+		entry.Attributes[tui.SpanIDAttribute] = hex.EncodeToString(record.Log.SpanId)
+	}
+
+	return entry
+}
+
+// extractResourceAttributes extracts the attributes of an OTLP resource
+func extractResourceAttributes(resource *resourcepb.Resource) map[string]string {
+	attributes := make(map[string]string)
+	if resource == nil {
+		return attributes
+	}
+	for _, attr := range resource.Attributes {
+		if attr.Key != "" && attr.Value != nil {
+			attributes[attr.Key] = extractStringFromAnyValue(attr.Value)
+		}
+	}
+	return attributes
+}
+
 // extractLogEntryFromOTLPRecord extracts a LogEntry from a single OTLP record
 func extractLogEntryFromOTLPRecord(record *logspb.LogRecord) *tui.LogEntry {
 	// Use the new function with empty resource attributes for backwards compatibility
@@ -226,6 +266,9 @@ func extractMessageFromBody(body *commonpb.AnyValue) string {
 		return fmt.Sprintf("%.2f", v.DoubleValue)
 	case *commonpb.AnyValue_BoolValue:
 		return fmt.Sprintf("%t", v.BoolValue)
+	case *commonpb.AnyValue_KvlistValue, *commonpb.AnyValue_ArrayValue:
+		// Structured bodies are shown as JSON
+		return formatNestedAnyValue(body)
 	default:
 		return fmt.Sprintf("%v", body)
 	}
@@ -246,11 +289,56 @@ func extractStringFromAnyValue(value *commonpb.AnyValue) string {
 		return fmt.Sprintf("%.2f", v.DoubleValue)
 	case *commonpb.AnyValue_BoolValue:
 		return fmt.Sprintf("%t", v.BoolValue)
+	case *commonpb.AnyValue_KvlistValue, *commonpb.AnyValue_ArrayValue:
+		return formatNestedAnyValue(value)
 	default:
 		return fmt.Sprintf("%v", v)
 	}
 }
 
+// formatNestedAnyValue formats an OTLP array or key-value list as compact JSON
+func formatNestedAnyValue(value *commonpb.AnyValue) string {
+	data, err := json.Marshal(anyValueToInterface(value))
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// anyValueToInterface converts an OTLP AnyValue to the equivalent Go value,
+// keeping the types of nested values
+func anyValueToInterface(value *commonpb.AnyValue) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.Value.(type) {
+	case *commonpb.AnyValue_StringValue:
+		return v.StringValue
+	case *commonpb.AnyValue_IntValue:
+		return v.IntValue
+	case *commonpb.AnyValue_DoubleValue:
+		return v.DoubleValue
+	case *commonpb.AnyValue_BoolValue:
+		return v.BoolValue
+	case *commonpb.AnyValue_BytesValue:
+		return v.BytesValue
+	case *commonpb.AnyValue_ArrayValue:
+		values := make([]interface{}, 0, len(v.ArrayValue.GetValues()))
+		for _, item := range v.ArrayValue.GetValues() {
+			values = append(values, anyValueToInterface(item))
+		}
+		return values
+	case *commonpb.AnyValue_KvlistValue:
+		fields := make(map[string]interface{}, len(v.KvlistValue.GetValues()))
+		for _, kv := range v.KvlistValue.GetValues() {
+			fields[kv.Key] = anyValueToInterface(kv.Value)
+		}
+		return fields
+	}
+	return nil
+}
+
 // convertLogEntryToOTLPRecord converts a LogEntry back to OTLP LogRecord for analysis
 func convertLogEntryToOTLPRecord(entry *tui.LogEntry) *logspb.LogRecord {
 	// Convert severity string back to OTLP severity number
@@ -34,18 +34,56 @@ type Config struct {
 	AIModel              string        `mapstructure:"ai-model"`
 	Files                []string      `mapstructure:"files"`
 	Follow               bool          `mapstructure:"follow"`
+	From                 string        `mapstructure:"from"`
+	TailLines            int           `mapstructure:"tail-lines"`
+	Replay               string        `mapstructure:"replay"`
 	OTLPEnabled          bool          `mapstructure:"otlp-enabled"`
 	OTLPGRPCPort         int           `mapstructure:"otlp-grpc-port"`
 	OTLPHTTPPort         int           `mapstructure:"otlp-http-port"`
+	OTLPBindAddress      string        `mapstructure:"otlp-bind-address"`
+	OTLPTLSCert          string        `mapstructure:"otlp-tls-cert"`
+	OTLPTLSKey           string        `mapstructure:"otlp-tls-key"`
+	OTLPTLSClientCA      string        `mapstructure:"otlp-tls-client-ca"`
+	OTLPAuthToken        string        `mapstructure:"otlp-auth-token"`
+	OTLPAuthUser         string        `mapstructure:"otlp-auth-user"`
+	OTLPAuthPassword     string        `mapstructure:"otlp-auth-password"`
+	OTLPMaxPayload       int           `mapstructure:"otlp-max-payload"`
+	SyslogEnabled        bool          `mapstructure:"syslog-enabled"`
+	SyslogUDPPort        int           `mapstructure:"syslog-udp-port"`
+	SyslogTCPPort        int           `mapstructure:"syslog-tcp-port"`
+	SyslogTLSCert        string        `mapstructure:"syslog-tls-cert"`
+	SyslogTLSKey         string        `mapstructure:"syslog-tls-key"`
 	VmlogsURL            string        `mapstructure:"vmlogs-url"`
 	VmlogsUser           string        `mapstructure:"vmlogs-user"`
 	VmlogsPassword       string        `mapstructure:"vmlogs-password"`
 	VmlogsQuery          string        `mapstructure:"vmlogs-query"`
+	VmlogsStart          string        `mapstructure:"vmlogs-start"`
+	VmlogsEnd            string        `mapstructure:"vmlogs-end"`
+	VmlogsLimit          int           `mapstructure:"vmlogs-limit"`
+	VmlogsFollow         bool          `mapstructure:"vmlogs-follow"`
+	K8s                  bool          `mapstructure:"k8s"`
+	K8sNamespace         string        `mapstructure:"k8s-namespace"`
+	K8sAllNamespaces     bool          `mapstructure:"k8s-all-namespaces"`
+	K8sSelector          string        `mapstructure:"k8s-selector"`
+	K8sContainer         string        `mapstructure:"k8s-container"`
+	K8sTail              int           `mapstructure:"k8s-tail"`
+	K8sContext           string        `mapstructure:"k8s-context"`
+	Kubeconfig           string        `mapstructure:"kubeconfig"`
 	Skin                 string        `mapstructure:"skin"`
 	StopWords            []string      `mapstructure:"stop-words"`
+	CorrelationKeys      []string      `mapstructure:"correlation-keys"`
 	Format               string        `mapstructure:"format"`
 	DisableVersionCheck  bool          `mapstructure:"disable-version-check"`
 	ReverseScrollWheel   bool          `mapstructure:"reverse-scroll-wheel"`
+	SaveSession          string        `mapstructure:"save-session"`
+	LoadSession          string        `mapstructure:"load-session"`
+	PatternsState        string        `mapstructure:"patterns-state"`
+	NewPatternBaseline   time.Duration `mapstructure:"new-pattern-baseline"`
+	AnomalyThreshold     float64       `mapstructure:"anomaly-threshold"`
+	Multiline            string        `mapstructure:"multiline"`
+	MultilineStart       string        `mapstructure:"multiline-start"`
+	MultilineContinue    string        `mapstructure:"multiline-continue"`
+	MultilineTimeout     time.Duration `mapstructure:"multiline-timeout"`
 }
 
 var (
@@ -70,6 +108,21 @@ Supports OTLP (OpenTelemetry) format natively, with automatic detection of JSON,
   
   # Use glob patterns to read multiple files
   gonzo -f "/var/log/*.log" --follow
+
+  # Read rotated and compressed history (app.log.2.gz, app.log.1) before tailing app.log
+  gonzo -f "/var/log/app.log*" --follow
+
+  # Also follow files created later, e.g. logs of new pods
+  gonzo -f "/var/log/pods/*/*/*.log" --follow
+
+  # Start with the last 1000 lines of a huge file
+  gonzo -f big.log --follow --tail-lines=1000
+
+  # Continue where the previous run stopped
+  gonzo -f big.log --follow --from=checkpoint
+
+  # Re-watch an incident at 10x speed, paced by the log timestamps
+  gonzo -f incident.log --replay=10x
   
   # Stream logs from kubectl  
   kubectl logs -f deployment/my-app | gonzo
@@ -91,6 +144,9 @@ Supports OTLP (OpenTelemetry) format natively, with automatic detection of JSON,
   
   # With custom ports
   gonzo --otlp-enabled --otlp-grpc-port=4317 --otlp-http-port=4318
+
+  # OTLP over TLS on one interface, requiring a bearer token (or GONZO_OTLP_AUTH_TOKEN)
+  gonzo --otlp-enabled --otlp-bind-address=10.0.0.5 --otlp-tls-cert=server.crt --otlp-tls-key=server.key --otlp-auth-token=secret
   
   # Stream logs from Victoria Logs
   gonzo --vmlogs-url="http://localhost:9428" --vmlogs-query="*"
@@ -103,6 +159,27 @@ Supports OTLP (OpenTelemetry) format natively, with automatic detection of JSON,
   export GONZO_VMLOGS_PASSWORD="mypass"  
   gonzo --vmlogs-url="https://vmlogs.example.com" --vmlogs-query='service:"myapp"'
 
+  # Analyze a past incident from Victoria Logs
+  gonzo --vmlogs-url="http://localhost:9428" --vmlogs-query='level:error' --vmlogs-start="2024-05-01 14:00" --vmlogs-end="2024-05-01 16:00"
+
+  # Load the last 2 hours, then keep tailing
+  gonzo --vmlogs-url="http://localhost:9428" --vmlogs-start=2h --vmlogs-follow
+
+  # Follow Kubernetes pods by label selector (uses ~/.kube/config)
+  gonzo --k8s --k8s-namespace=shop --k8s-selector="app=checkout"
+
+  # Follow one container across all namespaces in another cluster context
+  gonzo --k8s --k8s-all-namespaces --k8s-container=istio-proxy --k8s-context=staging
+
+  # Receive syslog from rsyslog/syslog-ng (UDP and TCP on port 5514)
+  gonzo --syslog-enabled
+
+  # Syslog over TLS on port 6514
+  gonzo --syslog-enabled --syslog-udp-port=0 --syslog-tcp-port=6514 --syslog-tls-cert=server.crt --syslog-tls-key=server.key
+
+  # Combine sources, e.g. OTLP next to log files (filter with source=otlp)
+  gonzo --otlp-enabled -f "/var/log/nginx/*.log" --follow
+
   # Using a custom color scheme/skin
   gonzo --skin=dracula
 
@@ -115,7 +192,18 @@ Supports OTLP (OpenTelemetry) format natively, with automatic detection of JSON,
 
   # Use built-in formats explicitly
   gonzo --format=json -f structured.log
-  gonzo --format=text -f plain.log`,
+  gonzo --format=text -f plain.log
+  gonzo --format=logfmt -f service.log
+
+  # Save the investigation on quit and reopen it later
+  gonzo -f app.log --save-session=incident-42.gonzo
+  gonzo --load-session=incident-42.gonzo
+
+  # Remember log patterns between runs and flag new ones
+  gonzo -f app.log --patterns-state=my-app
+
+  # Keep Java stack traces together as single log entries
+  gonzo -f app.log --multiline=java`,
 		RunE: runApp,
 	}
 
@@ -146,18 +234,58 @@ func init() {
 	rootCmd.Flags().String("ai-model", "", "AI model to use for log analysis (auto-selects best available if not specified)")
 	rootCmd.Flags().StringSliceP("file", "f", []string{}, "Files or file globs to read logs from (can specify multiple)")
 	rootCmd.Flags().Bool("follow", false, "Follow log files like 'tail -f' (watch for new lines in real-time)")
+	rootCmd.Flags().String("from", "start", "Where to start reading files: start, end, or checkpoint (resume where the last run stopped, positions kept in ~/.config/gonzo/state/)")
+	rootCmd.Flags().Int("tail-lines", 0, "Start reading every file this many lines before its end (files with a checkpoint resume from it)")
+	rootCmd.Flags().String("replay", "", "Replay input paced by the original log timestamps at this speed: 1x, 10x, max (without a value: 1x)")
+	rootCmd.Flags().Lookup("replay").NoOptDefVal = "1x"
 	rootCmd.Flags().Bool("otlp-enabled", false, "Enable OTLP listener to receive logs via OpenTelemetry protocol (gRPC and HTTP)")
 	rootCmd.Flags().Int("otlp-grpc-port", 4317, "Port for OTLP gRPC listener (default: 4317)")
 	rootCmd.Flags().Int("otlp-http-port", 4318, "Port for OTLP HTTP listener (default: 4318)")
+	rootCmd.Flags().String("otlp-bind-address", "", "Address the OTLP listeners bind to, e.g. 127.0.0.1 (default: all interfaces)")
+	rootCmd.Flags().String("otlp-tls-cert", "", "TLS certificate file for the OTLP listeners (serves gRPC and HTTP over TLS together with --otlp-tls-key)")
+	rootCmd.Flags().String("otlp-tls-key", "", "TLS private key file for the OTLP listeners")
+	rootCmd.Flags().String("otlp-tls-client-ca", "", "CA file to verify OTLP client certificates against (requires client certificates)")
+	rootCmd.Flags().String("otlp-auth-token", "", "Bearer token OTLP requests have to carry (can also use GONZO_OTLP_AUTH_TOKEN env var)")
+	rootCmd.Flags().String("otlp-auth-user", "", "Basic auth user OTLP requests have to carry (can also use GONZO_OTLP_AUTH_USER env var)")
+	rootCmd.Flags().String("otlp-auth-password", "", "Basic auth password for --otlp-auth-user (can also use GONZO_OTLP_AUTH_PASSWORD env var)")
+	rootCmd.Flags().Int("otlp-max-payload", 4, "Largest OTLP request accepted, in MiB after decompression")
+	rootCmd.Flags().Bool("syslog-enabled", false, "Enable syslog listener to receive RFC 5424 and RFC 3164 messages over UDP and TCP")
+	rootCmd.Flags().Int("syslog-udp-port", 5514, "Port for the syslog UDP listener (0 disables UDP)")
+	rootCmd.Flags().Int("syslog-tcp-port", 5514, "Port for the syslog TCP listener, octet-counting or newline framing (0 disables TCP)")
+	rootCmd.Flags().String("syslog-tls-cert", "", "TLS certificate file, serves the syslog TCP listener over TLS (requires --syslog-tls-key)")
+	rootCmd.Flags().String("syslog-tls-key", "", "TLS private key file for the syslog TCP listener")
 	rootCmd.Flags().String("vmlogs-url", "", "Victoria Logs URL endpoint for streaming logs (e.g., http://localhost:9428)")
 	rootCmd.Flags().String("vmlogs-user", "", "Victoria Logs basic auth username (can also use GONZO_VMLOGS_USER env var)")
 	rootCmd.Flags().String("vmlogs-password", "", "Victoria Logs basic auth password (can also use GONZO_VMLOGS_PASSWORD env var)")
 	rootCmd.Flags().String("vmlogs-query", "*", "Victoria Logs query (LogsQL) to use for streaming (default: '*' for all logs)")
+	rootCmd.Flags().String("vmlogs-start", "", "Query Victoria Logs history from this time instead of tailing (e.g. 2h for 2 hours ago, 2024-05-01 14:00, RFC 3339)")
+	rootCmd.Flags().String("vmlogs-end", "", "End of the Victoria Logs history query (default: now)")
+	rootCmd.Flags().Int("vmlogs-limit", 0, "Maximum number of logs returned by the Victoria Logs history query, the most recent ones (0 for no limit)")
+	rootCmd.Flags().Bool("vmlogs-follow", false, "Continue with live tailing after the Victoria Logs history query, from where the query ended")
+	rootCmd.Flags().Bool("k8s", false, "Follow Kubernetes pod logs via the API server (kubeconfig aware)")
+	rootCmd.Flags().String("k8s-namespace", "", "Kubernetes namespace to follow (default: namespace of the kubeconfig context)")
+	rootCmd.Flags().Bool("k8s-all-namespaces", false, "Follow pods in all Kubernetes namespaces")
+	rootCmd.Flags().String("k8s-selector", "", "Kubernetes label selector for the pods to follow (e.g., app=checkout)")
+	rootCmd.Flags().String("k8s-container", "", "Only follow containers with this name (default: all containers)")
+	rootCmd.Flags().Int("k8s-tail", 100, "Existing log lines to show per running container on startup (-1 for all)")
+	rootCmd.Flags().String("k8s-context", "", "Kubeconfig context to use (default: current context)")
+	rootCmd.Flags().String("kubeconfig", "", "Path to the kubeconfig file (default: $KUBECONFIG or ~/.kube/config)")
 	rootCmd.Flags().StringP("skin", "s", "default", "Color scheme/skin to use (default, or name of a skin file in ~/.config/gonzo/skins/)")
 	rootCmd.Flags().StringSlice("stop-words", []string{}, "Additional stop words to filter out from analysis (adds to built-in list)")
-	rootCmd.Flags().String("format", "", "Log format to use (auto-detect if not specified). Can be: otlp, json, text, or a custom format name from ~/.config/gonzo/formats/")
+	rootCmd.Flags().StringSlice("correlation-keys", []string{"trace_id", "request_id", "correlation_id"}, "Attributes the correlation view (g) can group logs by, e.g. trace_id,request_id")
+	rootCmd.Flags().String("format", "", "Log format to use (auto-detect if not specified). Can be: otlp, json, logfmt, text, or a custom format name from ~/.config/gonzo/formats/")
 	rootCmd.Flags().Bool("disable-version-check", false, "Disable automatic version checking on startup")
 	rootCmd.Flags().Bool("reverse-scroll-wheel", false, "Reverse scroll wheel direction (natural scrolling)")
+	rootCmd.Flags().String("save-session", "", "Save the dashboard session to this file on quit (without a value: ~/.config/gonzo/sessions/session-<time>.gonzo)")
+	rootCmd.Flags().Lookup("save-session").NoOptDefVal = autoSessionPath
+	rootCmd.Flags().String("load-session", "", "Restore a dashboard session previously saved with --save-session")
+	rootCmd.Flags().String("patterns-state", "", "Keep learned log patterns across runs under this name (~/.config/gonzo/patterns/<name>.json) or file path, and flag patterns not seen before")
+	rootCmd.Flags().Duration("new-pattern-baseline", 2*time.Minute, "Learning period before log patterns are flagged as new (ignored when --patterns-state loads known patterns)")
+	rootCmd.Flags().Float64("anomaly-threshold", 3.0, "Z-score above which a log rate spike is flagged as an anomaly (0 disables anomaly detection)")
+	rootCmd.Flags().String("multiline", "", "Join multi-line records such as stack traces using built-in presets: java, python, go, node (comma separated)")
+	rootCmd.Flags().String("multiline-start", "", "Regex matching the first line of a multi-line record (other lines are appended to the previous record)")
+	rootCmd.Flags().String("multiline-continue", "", "Regex matching continuation lines of a multi-line record")
+	rootCmd.Flags().Duration("multiline-timeout", 0, "Emit a pending multi-line record after this much idle time (default 500ms)")
 
 	// Bind flags to viper
 	viper.BindPFlag("memory-size", rootCmd.Flags().Lookup("memory-size"))
@@ -167,21 +295,60 @@ func init() {
 	viper.BindPFlag("ai-model", rootCmd.Flags().Lookup("ai-model"))
 	viper.BindPFlag("files", rootCmd.Flags().Lookup("file"))
 	viper.BindPFlag("follow", rootCmd.Flags().Lookup("follow"))
+	viper.BindPFlag("from", rootCmd.Flags().Lookup("from"))
+	viper.BindPFlag("tail-lines", rootCmd.Flags().Lookup("tail-lines"))
+	viper.BindPFlag("replay", rootCmd.Flags().Lookup("replay"))
 	viper.BindPFlag("otlp-enabled", rootCmd.Flags().Lookup("otlp-enabled"))
 	viper.BindPFlag("otlp-grpc-port", rootCmd.Flags().Lookup("otlp-grpc-port"))
 	viper.BindPFlag("otlp-http-port", rootCmd.Flags().Lookup("otlp-http-port"))
+	viper.BindPFlag("otlp-bind-address", rootCmd.Flags().Lookup("otlp-bind-address"))
+	viper.BindPFlag("otlp-tls-cert", rootCmd.Flags().Lookup("otlp-tls-cert"))
+	viper.BindPFlag("otlp-tls-key", rootCmd.Flags().Lookup("otlp-tls-key"))
+	viper.BindPFlag("otlp-tls-client-ca", rootCmd.Flags().Lookup("otlp-tls-client-ca"))
+	viper.BindPFlag("otlp-auth-token", rootCmd.Flags().Lookup("otlp-auth-token"))
+	viper.BindPFlag("otlp-auth-user", rootCmd.Flags().Lookup("otlp-auth-user"))
+	viper.BindPFlag("otlp-auth-password", rootCmd.Flags().Lookup("otlp-auth-password"))
+	viper.BindPFlag("otlp-max-payload", rootCmd.Flags().Lookup("otlp-max-payload"))
+	viper.BindPFlag("syslog-enabled", rootCmd.Flags().Lookup("syslog-enabled"))
+	viper.BindPFlag("syslog-udp-port", rootCmd.Flags().Lookup("syslog-udp-port"))
+	viper.BindPFlag("syslog-tcp-port", rootCmd.Flags().Lookup("syslog-tcp-port"))
+	viper.BindPFlag("syslog-tls-cert", rootCmd.Flags().Lookup("syslog-tls-cert"))
+	viper.BindPFlag("syslog-tls-key", rootCmd.Flags().Lookup("syslog-tls-key"))
 	viper.BindPFlag("vmlogs-url", rootCmd.Flags().Lookup("vmlogs-url"))
 	viper.BindPFlag("vmlogs-user", rootCmd.Flags().Lookup("vmlogs-user"))
 	viper.BindPFlag("vmlogs-password", rootCmd.Flags().Lookup("vmlogs-password"))
 	viper.BindPFlag("vmlogs-query", rootCmd.Flags().Lookup("vmlogs-query"))
+	viper.BindPFlag("vmlogs-start", rootCmd.Flags().Lookup("vmlogs-start"))
+	viper.BindPFlag("vmlogs-end", rootCmd.Flags().Lookup("vmlogs-end"))
+	viper.BindPFlag("vmlogs-limit", rootCmd.Flags().Lookup("vmlogs-limit"))
+	viper.BindPFlag("vmlogs-follow", rootCmd.Flags().Lookup("vmlogs-follow"))
+	viper.BindPFlag("k8s", rootCmd.Flags().Lookup("k8s"))
+	viper.BindPFlag("k8s-namespace", rootCmd.Flags().Lookup("k8s-namespace"))
+	viper.BindPFlag("k8s-all-namespaces", rootCmd.Flags().Lookup("k8s-all-namespaces"))
+	viper.BindPFlag("k8s-selector", rootCmd.Flags().Lookup("k8s-selector"))
+	viper.BindPFlag("k8s-container", rootCmd.Flags().Lookup("k8s-container"))
+	viper.BindPFlag("k8s-tail", rootCmd.Flags().Lookup("k8s-tail"))
+	viper.BindPFlag("k8s-context", rootCmd.Flags().Lookup("k8s-context"))
+	viper.BindPFlag("kubeconfig", rootCmd.Flags().Lookup("kubeconfig"))
 	viper.BindPFlag("skin", rootCmd.Flags().Lookup("skin"))
 	viper.BindPFlag("stop-words", rootCmd.Flags().Lookup("stop-words"))
+	viper.BindPFlag("correlation-keys", rootCmd.Flags().Lookup("correlation-keys"))
 	viper.BindPFlag("format", rootCmd.Flags().Lookup("format"))
 	viper.BindPFlag("disable-version-check", rootCmd.Flags().Lookup("disable-version-check"))
 	viper.BindPFlag("reverse-scroll-wheel", rootCmd.Flags().Lookup("reverse-scroll-wheel"))
+	viper.BindPFlag("save-session", rootCmd.Flags().Lookup("save-session"))
+	viper.BindPFlag("load-session", rootCmd.Flags().Lookup("load-session"))
+	viper.BindPFlag("patterns-state", rootCmd.Flags().Lookup("patterns-state"))
+	viper.BindPFlag("new-pattern-baseline", rootCmd.Flags().Lookup("new-pattern-baseline"))
+	viper.BindPFlag("anomaly-threshold", rootCmd.Flags().Lookup("anomaly-threshold"))
+	viper.BindPFlag("multiline", rootCmd.Flags().Lookup("multiline"))
+	viper.BindPFlag("multiline-start", rootCmd.Flags().Lookup("multiline-start"))
+	viper.BindPFlag("multiline-continue", rootCmd.Flags().Lookup("multiline-continue"))
+	viper.BindPFlag("multiline-timeout", rootCmd.Flags().Lookup("multiline-timeout"))
 
-	// Add version command
+	// Add subcommands
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(reportCmd)
 }
 
 func initConfig() {
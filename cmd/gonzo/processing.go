@@ -1,13 +1,67 @@
 package main
 
 import (
+	"maps"
+	"sort"
 	"strings"
 
 	"github.com/control-theory/gonzo/internal/analyzer"
+	"github.com/control-theory/gonzo/internal/k8s"
 	"github.com/control-theory/gonzo/internal/otlplog"
+	"github.com/control-theory/gonzo/internal/otlpreceiver"
 	"github.com/control-theory/gonzo/internal/tui"
 )
 
+// processInputLine processes a line from an input source, tagging the log entries
+// parsed from it with the source name and attributes
+func (m *simpleTuiModel) processInputLine(line inputLine) {
+	if line.ended {
+		m.flushJSONAccumulation(line.source)
+		return
+	}
+
+	m.lineStream = streamOf(line)
+	m.lineAttributes = line.attributes
+	if line.source != "" {
+		m.lineAttributes = make(map[string]string, len(line.attributes)+1)
+		for key, value := range line.attributes {
+			m.lineAttributes[key] = value
+		}
+		m.lineAttributes[tui.SourceAttribute] = line.source
+	}
+	if line.record != nil {
+		m.processOTLPRecord(*line.record)
+	} else {
+		m.processLogLine(line.text)
+	}
+	m.lineAttributes = nil
+}
+
+// processOTLPRecord processes a record of the OTLP receiver. It is turned into
+// a log entry directly, without format detection or parsing.
+func (m *simpleTuiModel) processOTLPRecord(record otlpreceiver.Record) {
+	m.logCount++
+
+	// Records of one export request share their resource, convert it only once
+	if record.Resource != m.otlpResource || m.otlpResourceAttributes == nil {
+		m.otlpResource = record.Resource
+		m.otlpResourceAttributes = extractResourceAttributes(record.Resource)
+	}
+
+	logEntry := extractLogEntryFromReceivedRecord(record, m.otlpResourceAttributes)
+	result := m.otlpAnalyzer.AnalyzeOTLPRecord(record.Log)
+
+	// Trace and span IDs are unique per request, not worth counting
+	attributes := make(map[string]string, len(logEntry.Attributes))
+	for key, value := range logEntry.Attributes {
+		if key != tui.TraceIDAttribute && key != tui.SpanIDAttribute {
+			attributes[key] = value
+		}
+	}
+
+	m.processSingleLogEntry(result, attributes, logEntry)
+}
+
 // processLogLine processes a single log line and updates frequency memory
 func (m *simpleTuiModel) processLogLine(line string) {
 	// Early filter: Skip OTLP collector logs about traces/metrics processing
@@ -51,7 +105,7 @@ func (m *simpleTuiModel) processLogLine(line string) {
 				for _, entry := range logEntries {
 					// Analyze each log entry for frequency data
 					entryResult := m.otlpAnalyzer.AnalyzeOTLPRecord(convertLogEntryToOTLPRecord(entry))
-					entryAttributes := entry.Attributes // Already includes resource + record attributes
+					entryAttributes := maps.Clone(entry.Attributes) // Resource + record attributes, copied so the file offset added to the entry is not counted
 
 					m.processSingleLogEntry(entryResult, entryAttributes, entry)
 				}
@@ -141,6 +195,22 @@ func (m *simpleTuiModel) processLogLine(line string) {
 
 // processSingleLogEntry processes a single log entry for frequency analysis and dashboard updates
 func (m *simpleTuiModel) processSingleLogEntry(result *analyzer.AnalysisResult, attributes map[string]string, logEntry *tui.LogEntry) {
+	// Attach source attributes (gonzo.source, Kubernetes pod metadata) of the line being processed
+	if len(m.lineAttributes) > 0 && logEntry != nil {
+		if logEntry.Attributes == nil {
+			logEntry.Attributes = make(map[string]string, len(m.lineAttributes))
+		}
+		if attributes == nil {
+			attributes = make(map[string]string, len(m.lineAttributes))
+		}
+		for key, value := range m.lineAttributes {
+			logEntry.Attributes[key] = value
+			if key != tui.FileOffsetAttribute { // Unique per entry, not worth counting
+				attributes[key] = value
+			}
+		}
+	}
+
 	// Add results to frequency memory
 	m.freqMemory.AddWords(result.Words)
 	m.freqMemory.AddPhrases(result.Phrases)
@@ -151,8 +221,14 @@ func (m *simpleTuiModel) processSingleLogEntry(result *analyzer.AnalysisResult,
 		// Count severity for this interval
 		m.severityCounts.AddCount(logEntry.Severity)
 
-		updateMsg := tui.UpdateMsg{NewLogEntry: logEntry}
-		m.dashboard.Update(updateMsg)
+		if m.reportBuilder != nil {
+			m.reportBuilder.AddEntry(logEntry.Severity, tui.GetServiceName(*logEntry), logEntry.Message, logEntry.OrigTimestamp)
+		}
+
+		if m.dashboard != nil {
+			updateMsg := tui.UpdateMsg{NewLogEntry: logEntry}
+			m.dashboard.Update(updateMsg)
+		}
 	}
 }
 
@@ -198,54 +274,66 @@ func isOTLPSignalLog(message string) bool {
 	return false
 }
 
-// tryAccumulateJSON attempts to accumulate multi-line JSON and process when complete
+// jsonStream identifies the input stream a line belongs to. Multi-line JSON is
+// accumulated per stream, so that the interleaved lines of different sources,
+// files or containers never end up in the same object.
+type jsonStream struct {
+	source string
+	name   string // File path or pod container, empty for sources of one stream
+}
+
+// jsonAccumulator holds a multi-line JSON object of one stream until it is complete
+type jsonAccumulator struct {
+	buffer     strings.Builder
+	depth      int               // Net JSON object/array nesting depth so far
+	attributes map[string]string // Attributes of the line that opened the object
+}
+
+// streamOf returns the stream of an input line
+func streamOf(line inputLine) jsonStream {
+	if path := line.attributes[tui.FilePathAttribute]; path != "" {
+		return jsonStream{source: line.source, name: path}
+	}
+	if pod := line.attributes[k8s.AttrPodName]; pod != "" {
+		return jsonStream{
+			source: line.source,
+			name:   line.attributes[k8s.AttrNamespaceName] + "/" + pod + "/" + line.attributes[k8s.AttrContainerName],
+		}
+	}
+	return jsonStream{source: line.source}
+}
+
+// tryAccumulateJSON attempts to accumulate multi-line JSON of the current line's
+// stream and processes it when complete
 func (m *simpleTuiModel) tryAccumulateJSON(line string) bool {
-	// Check if this line could be part of a JSON object
-	trimmed := strings.TrimSpace(line)
+	accumulator := m.jsonAccumulators[m.lineStream]
 
 	// If we're not currently accumulating JSON, check if this line starts a JSON object
-	if !m.inJsonObject {
-		if trimmed == "{" || strings.HasPrefix(trimmed, "{") {
-			// Start accumulating JSON
-			m.inJsonObject = true
-			m.jsonBuffer.Reset()
-			m.jsonDepth = 0
-			m.jsonBuffer.WriteString(line)
-			m.jsonBuffer.WriteString("\n")
-
-			// Count braces in this line
-			m.jsonDepth += countJSONDepth(line)
-
-			// If depth is already 0, we have a complete single-line JSON
-			if m.jsonDepth <= 0 {
-				completeJSON := strings.TrimSpace(m.jsonBuffer.String())
-				m.resetJSONAccumulation()
-				m.processCompleteJSON(completeJSON)
-				return true
-			}
+	if accumulator == nil {
+		if !strings.HasPrefix(strings.TrimSpace(line), "{") {
+			// Not starting JSON, process normally
+			return false
+		}
 
-			return true // Line was accumulated
+		// Start accumulating JSON
+		accumulator = &jsonAccumulator{attributes: m.lineAttributes}
+		if m.jsonAccumulators == nil {
+			m.jsonAccumulators = make(map[jsonStream]*jsonAccumulator)
 		}
-		// Not starting JSON, process normally
-		return false
+		m.jsonAccumulators[m.lineStream] = accumulator
 	}
 
-	// We're already accumulating JSON, add this line
-	m.jsonBuffer.WriteString(line)
-	m.jsonBuffer.WriteString("\n")
-
-	// Update depth count
-	m.jsonDepth += countJSONDepth(line)
+	accumulator.buffer.WriteString(line)
+	accumulator.buffer.WriteString("\n")
 
 	// If depth reaches 0 or below, we have a complete JSON object
-	if m.jsonDepth <= 0 {
-		completeJSON := strings.TrimSpace(m.jsonBuffer.String())
-		m.resetJSONAccumulation()
-		m.processCompleteJSON(completeJSON)
-		return true
+	accumulator.depth += countJSONDepth(line)
+	if accumulator.depth <= 0 {
+		delete(m.jsonAccumulators, m.lineStream)
+		m.processAccumulatedJSON(accumulator)
 	}
 
-	return true // Line was accumulated, waiting for more
+	return true // Line was accumulated
 }
 
 // countJSONDepth counts the net change in JSON nesting depth for a line
@@ -281,11 +369,38 @@ func countJSONDepth(line string) int {
 	return depth
 }
 
-// resetJSONAccumulation resets the JSON accumulation state
-func (m *simpleTuiModel) resetJSONAccumulation() {
-	m.inJsonObject = false
-	m.jsonDepth = 0
-	m.jsonBuffer.Reset()
+// flushJSONAccumulation processes the partially accumulated JSON of a source's
+// streams once the source has ended
+func (m *simpleTuiModel) flushJSONAccumulation(source string) {
+	var streams []jsonStream
+	for stream := range m.jsonAccumulators {
+		if stream.source == source {
+			streams = append(streams, stream)
+		}
+	}
+	// Flush in a stable order, files by path
+	sort.Slice(streams, func(i, j int) bool { return streams[i].name < streams[j].name })
+
+	for _, stream := range streams {
+		accumulator := m.jsonAccumulators[stream]
+		delete(m.jsonAccumulators, stream)
+		// The object never closed; process what we have so the lines are not lost
+		m.processAccumulatedJSON(accumulator)
+	}
+}
+
+// processAccumulatedJSON processes accumulated JSON with the attributes of the
+// line that opened it
+func (m *simpleTuiModel) processAccumulatedJSON(accumulator *jsonAccumulator) {
+	jsonStr := strings.TrimSpace(accumulator.buffer.String())
+	if jsonStr == "" {
+		return
+	}
+
+	lineAttributes := m.lineAttributes
+	m.lineAttributes = accumulator.attributes
+	m.processCompleteJSON(jsonStr)
+	m.lineAttributes = lineAttributes
 }
 
 // processCompleteJSON processes a complete JSON object (single or multi-line)
@@ -321,7 +436,7 @@ func (m *simpleTuiModel) processCompleteJSON(jsonStr string) {
 				for _, entry := range logEntries {
 					// Analyze each log entry for frequency data
 					entryResult := m.otlpAnalyzer.AnalyzeOTLPRecord(convertLogEntryToOTLPRecord(entry))
-					entryAttributes := entry.Attributes // Already includes resource + record attributes
+					entryAttributes := maps.Clone(entry.Attributes) // Resource + record attributes, copied so the file offset added to the entry is not counted
 
 					m.processSingleLogEntry(entryResult, entryAttributes, entry)
 				}
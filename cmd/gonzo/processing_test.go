@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/analyzer"
+	"github.com/control-theory/gonzo/internal/memory"
+	"github.com/control-theory/gonzo/internal/otlpreceiver"
+	"github.com/control-theory/gonzo/internal/tui"
+	otlpgrpc "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// benchmarkRecords is the number of log records of the generated export request
+const benchmarkRecords = 100
+
+// newBenchmarkModel creates a processing model without a dashboard, like the report command
+func newBenchmarkModel(b *testing.B) *simpleTuiModel {
+	b.Helper()
+	formatDetector, logConverter, customParser := newFormatComponents("", b.TempDir())
+	ctx, cancel := context.WithCancel(context.Background())
+	b.Cleanup(cancel)
+	return &simpleTuiModel{
+		formatDetector: formatDetector,
+		logConverter:   logConverter,
+		customParser:   customParser,
+		textAnalyzer:   analyzer.NewTextAnalyzer(),
+		otlpAnalyzer:   analyzer.NewOTLPAnalyzer(),
+		freqMemory:     memory.NewFrequencyMemory(10000),
+		severityCounts: &tui.SeverityCounts{},
+		ctx:            ctx,
+		cancelFunc:     cancel,
+	}
+}
+
+// stringValue returns an OTLP string attribute value
+func stringValue(value string) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}
+}
+
+// newBenchmarkRequest generates an export request of one resource and scope
+// with log records like those of an instrumented HTTP service
+func newBenchmarkRequest() *otlpgrpc.ExportLogsServiceRequest {
+	resource := &resourcepb.Resource{Attributes: []*commonpb.KeyValue{
+		{Key: "service.name", Value: stringValue("checkout")},
+		{Key: "service.version", Value: stringValue("1.4.2")},
+		{Key: "host.name", Value: stringValue("node-7")},
+		{Key: "k8s.namespace.name", Value: stringValue("shop")},
+	}}
+	scope := &commonpb.InstrumentationScope{Name: "checkout/http", Version: "0.9.0"}
+
+	severities := []logspb.SeverityNumber{
+		logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+		logspb.SeverityNumber_SEVERITY_NUMBER_WARN,
+		logspb.SeverityNumber_SEVERITY_NUMBER_ERROR,
+	}
+	start := time.Date(2024, 5, 1, 14, 0, 0, 0, time.UTC)
+
+	records := make([]*logspb.LogRecord, benchmarkRecords)
+	for i := range records {
+		severity := severities[i%len(severities)]
+		records[i] = &logspb.LogRecord{
+			TimeUnixNano:         uint64(start.Add(time.Duration(i) * time.Millisecond).UnixNano()),
+			ObservedTimeUnixNano: uint64(start.Add(time.Duration(i)*time.Millisecond + time.Second).UnixNano()),
+			SeverityNumber:       severity,
+			SeverityText:         logspb.SeverityNumber_name[int32(severity)][len("SEVERITY_NUMBER_"):],
+			Body:                 stringValue(fmt.Sprintf("handled POST /api/orders/%d in %dms", i, 20+i%50)),
+			Attributes: []*commonpb.KeyValue{
+				{Key: "http.method", Value: stringValue("POST")},
+				{Key: "http.route", Value: stringValue("/api/orders/{id}")},
+				{Key: "http.status_code", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(200 + 100*(i%3))}}},
+				{Key: "duration_ms", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: float64(20 + i%50)}}},
+			},
+			TraceId: []byte(fmt.Sprintf("%016d", i)),
+			SpanId:  []byte(fmt.Sprintf("%08d", i)),
+		}
+	}
+
+	return &otlpgrpc.ExportLogsServiceRequest{ResourceLogs: []*logspb.ResourceLogs{{
+		Resource:  resource,
+		ScopeLogs: []*logspb.ScopeLogs{{Scope: scope, LogRecords: records}},
+	}}}
+}
+
+// BenchmarkProcessOTLPRecord measures the records of the OTLP receiver
+// processed as they arrive, one typed record at a time
+func BenchmarkProcessOTLPRecord(b *testing.B) {
+	req := newBenchmarkRequest()
+	resourceLogs := req.ResourceLogs[0]
+	scopeLogs := resourceLogs.ScopeLogs[0]
+	records := make([]otlpreceiver.Record, len(scopeLogs.LogRecords))
+	for i, logRecord := range scopeLogs.LogRecords {
+		records[i] = otlpreceiver.Record{Resource: resourceLogs.Resource, Scope: scopeLogs.Scope, Log: logRecord}
+	}
+	m := newBenchmarkModel(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.processOTLPRecord(records[i%len(records)])
+	}
+}
+
+// BenchmarkProcessOTLPJSONLine measures the same records sent through the
+// text path as one OTLP JSON line each, which is how the receiver passed
+// them on before. Marshaling the lines is not included.
+func BenchmarkProcessOTLPJSONLine(b *testing.B) {
+	req := newBenchmarkRequest()
+	resourceLogs := req.ResourceLogs[0]
+	scopeLogs := resourceLogs.ScopeLogs[0]
+	lines := make([]string, len(scopeLogs.LogRecords))
+	for i, logRecord := range scopeLogs.LogRecords {
+		single := &otlpgrpc.ExportLogsServiceRequest{ResourceLogs: []*logspb.ResourceLogs{{
+			Resource:  resourceLogs.Resource,
+			ScopeLogs: []*logspb.ScopeLogs{{Scope: scopeLogs.Scope, LogRecords: []*logspb.LogRecord{logRecord}}},
+		}}}
+		line, err := protojson.Marshal(single)
+		if err != nil {
+			b.Fatal(err)
+		}
+		lines[i] = string(line)
+	}
+	m := newBenchmarkModel(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.processLogLine(lines[i%len(lines)])
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/control-theory/gonzo/internal/timestamp"
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// replayClock paces input lines by their original timestamps. The first line
+// with a timestamp anchors log time to wall time; every later line is due once
+// as much wall time passed as log time did, divided by the speed.
+type replayClock struct {
+	speed  float64 // Log time per wall time, 0 replays as fast as possible
+	label  string  // Speed as given on the command line (e.g. "10x")
+	parser *timestamp.Parser
+
+	started   bool
+	logStart  time.Time // Original time of the anchor line
+	wallStart time.Time // When the anchor line was replayed
+	paused    bool
+	pausedAt  time.Time
+	position  time.Time // Original time of the last replayed line
+}
+
+// replayReadyMsg signals that the pending replay line is due. Messages of an
+// older sequence were scheduled before a pause or seek and are ignored.
+type replayReadyMsg struct {
+	sequence int
+}
+
+// parseReplaySpeed parses a --replay value such as 1x, 10x, 0.5x or max
+func parseReplaySpeed(value string) (float64, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "max" {
+		return 0, nil
+	}
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(value, "x"), 64)
+	if err != nil || speed <= 0 {
+		return 0, fmt.Errorf("invalid --replay speed %q (use e.g. 1x, 10x or max)", value)
+	}
+	return speed, nil
+}
+
+// newReplayClock creates a replay clock for a --replay value
+func newReplayClock(value string) (*replayClock, error) {
+	speed, err := parseReplaySpeed(value)
+	if err != nil {
+		return nil, err
+	}
+	label := strings.ToLower(strings.TrimSpace(value))
+	if speed > 0 && !strings.HasSuffix(label, "x") {
+		label += "x"
+	}
+	return &replayClock{speed: speed, label: label, parser: timestamp.NewParser()}, nil
+}
+
+// timestampOf returns the original timestamp of a line, if it has one
+func (c *replayClock) timestampOf(text string) (time.Time, bool) {
+	result := c.parser.ParseFromText(text)
+	return result.Timestamp, result.Found
+}
+
+// wait returns how long a line with original time ts has to wait before it is
+// replayed. Lines older than the replay position (out of order) are due at once.
+func (c *replayClock) wait(ts time.Time, now time.Time) time.Duration {
+	if !c.started || c.speed == 0 {
+		return 0
+	}
+	due := c.wallStart.Add(time.Duration(float64(ts.Sub(c.logStart)) / c.speed))
+	return max(due.Sub(now), 0)
+}
+
+// replayed records that a line with original time ts was replayed
+func (c *replayClock) replayed(ts time.Time, now time.Time) {
+	if !c.started {
+		c.started = true
+		c.logStart = ts
+		c.wallStart = now
+	}
+	if ts.After(c.position) {
+		c.position = ts
+	}
+}
+
+// togglePause pauses or resumes the replay. Log time stands still while paused.
+func (c *replayClock) togglePause(now time.Time) {
+	if c.paused {
+		c.wallStart = c.wallStart.Add(now.Sub(c.pausedAt))
+	} else {
+		c.pausedAt = now
+	}
+	c.paused = !c.paused
+}
+
+// seek moves the replay forward by d of log time, the lines in between are
+// replayed at once
+func (c *replayClock) seek(d time.Duration) {
+	if c.started && c.speed > 0 {
+		c.wallStart = c.wallStart.Add(-time.Duration(float64(d) / c.speed))
+	}
+}
+
+// status returns the replay state shown in the status line
+func (c *replayClock) status() tui.ReplayStatusMsg {
+	return tui.ReplayStatusMsg{
+		Speed:    c.label,
+		Position: c.position,
+		Paused:   c.paused,
+	}
+}
+
+// replayLine holds a line until the replay clock reaches its timestamp
+func (m *simpleTuiModel) replayLine(line inputLine) tea.Cmd {
+	m.replayPending = &line
+	if line.record != nil {
+		m.replayPendingTime = recordTimestamp(line.record.Log)
+		m.replayPendingHasTime = !m.replayPendingTime.IsZero()
+	} else {
+		m.replayPendingTime, m.replayPendingHasTime = m.replay.timestampOf(line.text)
+	}
+	return m.replayNext()
+}
+
+// replayNext replays the pending line once it is due and then continues
+// reading input. Nothing is read while the replay is paused.
+func (m *simpleTuiModel) replayNext() tea.Cmd {
+	if m.replayPending == nil || m.replay.paused {
+		return nil
+	}
+
+	// Lines without a timestamp are replayed right away
+	now := time.Now()
+	if m.replayPendingHasTime {
+		if wait := m.replay.wait(m.replayPendingTime, now); wait > 0 {
+			sequence := m.replaySequence
+			return tea.Tick(wait, func(time.Time) tea.Msg {
+				return replayReadyMsg{sequence: sequence}
+			})
+		}
+		m.replay.replayed(m.replayPendingTime, now)
+	}
+
+	line := *m.replayPending
+	m.replayPending = nil
+	m.processInputLine(line)
+
+	if m.hasInput() && !m.finished {
+		return m.checkInputChannel()
+	}
+	return nil
+}
+
+// controlReplay applies a pause or seek requested from the dashboard
+func (m *simpleTuiModel) controlReplay(msg tui.ReplayControlMsg) tea.Cmd {
+	if msg.TogglePause {
+		m.replay.togglePause(time.Now())
+	}
+	if msg.Seek > 0 {
+		m.replay.seek(msg.Seek)
+	}
+
+	// Due times changed, reschedule the pending line
+	m.replaySequence++
+	return tea.Batch(m.updateReplayStatus(), m.replayNext())
+}
+
+// updateReplayStatus sends the replay state to the dashboard
+func (m *simpleTuiModel) updateReplayStatus() tea.Cmd {
+	newDashboard, cmd := m.dashboard.Update(m.replay.status())
+	m.dashboard = newDashboard.(*tui.DashboardModel)
+	return cmd
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/control-theory/gonzo/internal/analyzer"
+	"github.com/control-theory/gonzo/internal/filereader"
+	"github.com/control-theory/gonzo/internal/memory"
+	"github.com/control-theory/gonzo/internal/multiline"
+	"github.com/control-theory/gonzo/internal/output"
+	"github.com/control-theory/gonzo/internal/tui"
+
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report [files...]",
+	Short: "Analyze logs without the TUI and print a summary report",
+	Long: `Run the Gonzo analysis pipeline (format detection, word/attribute frequency,
+Drain3 patterns and severity counts) over files or stdin until the input ends,
+then print a summary report as text, JSON or Markdown.
+
+Useful in CI jobs and scripts where no terminal is available.`,
+	Example: `  # Summarize a log file
+  gonzo report -f application.log
+
+  # Attach a Markdown digest to a CI run
+  gonzo report -f "logs/*.log" --output-format=markdown --output=log-digest.md
+
+  # Machine-readable report from stdin
+  kubectl logs deployment/my-app | gonzo report -o json`,
+	RunE: runReport,
+}
+
+func init() {
+	reportCmd.Flags().StringSliceP("file", "f", []string{}, "Files or file globs to read logs from (can specify multiple, reads stdin if omitted)")
+	reportCmd.Flags().StringP("output-format", "o", "text", "Report format: text, json or markdown")
+	reportCmd.Flags().String("output", "", "Write the report to this file instead of stdout")
+	reportCmd.Flags().String("format", "", "Log format to use (auto-detect if not specified). Can be: otlp, json, logfmt, text, or a custom format name from ~/.config/gonzo/formats/")
+	reportCmd.Flags().String("multiline", "", "Join multi-line records such as stack traces using built-in presets: java, python, go, node (comma separated)")
+	reportCmd.Flags().String("multiline-start", "", "Regex matching the first line of a multi-line record")
+	reportCmd.Flags().String("multiline-continue", "", "Regex matching continuation lines of a multi-line record")
+	reportCmd.Flags().Int("top", 10, "Number of entries to include per report section (0 for all)")
+}
+
+// runReport processes the input to completion and writes the report
+func runReport(cmd *cobra.Command, args []string) error {
+	outputFormatName, _ := cmd.Flags().GetString("output-format")
+	reportFormat, err := output.ParseReportFormat(outputFormatName)
+	if err != nil {
+		return err
+	}
+
+	files, _ := cmd.Flags().GetStringSlice("file")
+	files = append(files, args...)
+	outputPath, _ := cmd.Flags().GetString("output")
+	top, _ := cmd.Flags().GetInt("top")
+
+	formatName, _ := cmd.Flags().GetString("format")
+	if formatName == "" {
+		formatName = cfg.Format
+	}
+
+	configDir := os.Getenv("HOME") + "/.config/gonzo"
+	formatDetector, logConverter, customParser := newFormatComponents(formatName, configDir)
+
+	multilineConfig := multiline.Config{Timeout: cfg.MultilineTimeout}
+	multilineConfig.Preset, _ = cmd.Flags().GetString("multiline")
+	multilineConfig.StartPattern, _ = cmd.Flags().GetString("multiline-start")
+	multilineConfig.ContinuationPattern, _ = cmd.Flags().GetString("multiline-continue")
+	if !multilineConfig.Enabled() {
+		multilineConfig.Preset = cfg.Multiline
+		multilineConfig.StartPattern = cfg.MultilineStart
+		multilineConfig.ContinuationPattern = cfg.MultilineContinue
+	}
+	multilineConfig = resolveMultilineConfig(multilineConfig, customParser)
+	if err := validateMultilineConfig(multilineConfig); err != nil {
+		return err
+	}
+
+	memorySize := cfg.MemorySize
+	if memorySize <= 0 {
+		memorySize = 10000
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Reuse the TUI processing pipeline without a dashboard
+	model := &simpleTuiModel{
+		formatDetector:  formatDetector,
+		logConverter:    logConverter,
+		customParser:    customParser,
+		multilineConfig: multilineConfig,
+		textAnalyzer:    analyzer.NewTextAnalyzerWithStopWords(cfg.StopWords),
+		otlpAnalyzer:    analyzer.NewOTLPAnalyzer(),
+		freqMemory:      memory.NewFrequencyMemory(memorySize),
+		severityCounts:  &tui.SeverityCounts{},
+		ctx:             ctx,
+		cancelFunc:      cancel,
+		inputChan:       make(chan inputLine, 100),
+		reportBuilder:   output.NewReportBuilder(),
+	}
+
+	var sources []string
+	if len(files) > 0 {
+		model.fileReader, err = filereader.New(files, filereader.Options{Multiline: multilineConfig})
+		if err != nil {
+			return fmt.Errorf("error setting up file reader: %w", err)
+		}
+		model.hasFileInput = true
+		sources = model.fileReader.GetFilePaths()
+		go model.readFilesAsync(model.addSource(sourceFile))
+	} else {
+		stat, _ := os.Stdin.Stat()
+		if (stat.Mode() & os.ModeCharDevice) != 0 {
+			return fmt.Errorf("no input: pipe logs to stdin or pass files with -f")
+		}
+		model.hasStdinData = true
+		sources = []string{"stdin"}
+		go model.readStdinAsync(model.lineSink(sourceStdin))
+	}
+	model.closeInputWhenDone()
+
+	for line := range model.inputChan {
+		model.processInputLine(line)
+	}
+
+	report := model.reportBuilder.Build(sources, model.logCount, model.freqMemory.GetSnapshot(), top)
+
+	if outputPath == "" {
+		return report.Write(os.Stdout, reportFormat)
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("error creating report file: %w", err)
+	}
+	if err := report.Write(file, reportFormat); err != nil {
+		file.Close()
+		return fmt.Errorf("error writing report: %w", err)
+	}
+	return file.Close()
+}
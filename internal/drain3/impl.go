@@ -1,6 +1,9 @@
 package drain3
 
 import (
+	"os"
+	"path/filepath"
+
 	goDrain "github.com/jaeyo/go-drain3/pkg/drain3"
 )
 
@@ -83,9 +86,10 @@ func New(config *Config) *Drain {
 }
 
 // AddLogMessage processes a single log message and returns the cluster it belongs to
-func (d *Drain) AddLogMessage(logMessage string) error {
-	_, _, err := d.Drain.AddLogMessage(logMessage)
-	return err
+// and whether that cluster was created for this message
+func (d *Drain) AddLogMessage(logMessage string) (*goDrain.LogCluster, bool, error) {
+	cluster, updateType, err := d.Drain.AddLogMessage(logMessage)
+	return cluster, updateType == goDrain.ClusterUpdateTypeCreated, err
 }
 
 // GetClusters returns the current clusters of log templates
@@ -93,6 +97,58 @@ func (d *Drain) GetClusters() []*goDrain.LogCluster {
 	return d.Drain.GetClusters()
 }
 
+// MarshalState serializes the parse tree and clusters so they can be restored later
+func (d *Drain) MarshalState() ([]byte, error) {
+	return d.Drain.MarshalJSON()
+}
+
+// LoadState replaces the parse tree and clusters with previously marshalled state
+func (d *Drain) LoadState(data []byte) error {
+	restored := &goDrain.Drain{}
+	if err := restored.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	d.Drain = restored
+	return nil
+}
+
+// SaveFile writes the parse tree and clusters to path as JSON
+func (d *Drain) SaveFile(path string) error {
+	data, err := d.MarshalState()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a failed save never clobbers existing state
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// LoadFile restores the parse tree and clusters from a file written by SaveFile
+func (d *Drain) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return d.LoadState(data)
+}
+
+// ResetCounts zeroes the size of every cluster while keeping the learned templates
+func (d *Drain) ResetCounts() {
+	for _, cluster := range d.GetClusters() {
+		cluster.Size = 0
+	}
+}
+
 func (d *Drain) Reset() error {
 	// Reset the Drain instance by reinitializing with stored config
 	newDrain, err := goDrain.NewDrain(
@@ -0,0 +1,70 @@
+package filereader
+
+import (
+	"strings"
+
+	"github.com/control-theory/gonzo/internal/multiline"
+)
+
+// recordAssembler joins the multi-line records of one file. It keeps the
+// offsets of the pending lines, so that every record carries the offset of its
+// first line.
+type recordAssembler struct {
+	path      string
+	assembler *multiline.Assembler // nil when multi-line assembly is disabled
+	offsets   []int64
+}
+
+func newRecordAssembler(path string, cfg multiline.Config) *recordAssembler {
+	ra := &recordAssembler{path: path}
+	if cfg.Enabled() {
+		// The config was validated in New
+		ra.assembler, _ = multiline.NewAssembler(cfg)
+	}
+	return ra
+}
+
+// add feeds a line starting at offset to the assembler and returns the records it completed
+func (ra *recordAssembler) add(text string, offset int64) []Line {
+	if ra.assembler == nil {
+		return []Line{{Text: text, Path: ra.path, Offset: offset}}
+	}
+	ra.offsets = append(ra.offsets, offset)
+	return ra.records(ra.assembler.Add(text))
+}
+
+// flush returns the record being assembled, if any
+func (ra *recordAssembler) flush() []Line {
+	if ra.assembler == nil {
+		return nil
+	}
+	if record, ok := ra.assembler.Flush(); ok {
+		return ra.records([]string{record})
+	}
+	return nil
+}
+
+// pending reports whether lines are waiting for the rest of their record
+func (ra *recordAssembler) pending() bool {
+	return len(ra.offsets) > 0
+}
+
+// resumeOffset returns where reading has to resume to not lose the pending
+// record, given the offset of the next line
+func (ra *recordAssembler) resumeOffset(offset int64) int64 {
+	if ra.pending() {
+		return ra.offsets[0]
+	}
+	return offset
+}
+
+func (ra *recordAssembler) records(texts []string) []Line {
+	lines := make([]Line, 0, len(texts))
+	for _, text := range texts {
+		// Records are their lines joined with newlines
+		n := min(strings.Count(text, "\n")+1, len(ra.offsets))
+		lines = append(lines, Line{Text: text, Path: ra.path, Offset: ra.offsets[0]})
+		ra.offsets = ra.offsets[n:]
+	}
+	return lines
+}
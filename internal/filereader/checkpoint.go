@@ -0,0 +1,123 @@
+package filereader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Checkpoint is the position up to which a file was read
+type Checkpoint struct {
+	Path    string    `json:"path"`
+	Device  uint64    `json:"device"`
+	Inode   uint64    `json:"inode"`
+	Offset  int64     `json:"offset"` // Offset of the first line not read yet
+	Updated time.Time `json:"updated"`
+}
+
+// CheckpointStore keeps the read position of files across runs. Checkpoints
+// are keyed by the device, inode and path of a file, so a file replaced under
+// the same name (e.g. by log rotation) starts without one. Every checkpoint is
+// a JSON file of its own in the store directory.
+type CheckpointStore struct {
+	dir   string
+	mu    sync.Mutex
+	dirty map[string]Checkpoint // Checkpoints updated since the last flush, by file name
+}
+
+// NewCheckpointStore creates a checkpoint store in dir, which is created on the first flush
+func NewCheckpointStore(dir string) *CheckpointStore {
+	return &CheckpointStore{
+		dir:   dir,
+		dirty: make(map[string]Checkpoint),
+	}
+}
+
+// checkpointFile returns the name of the file holding the checkpoint of a file.
+// The readable base name is followed by a hash of the full key.
+func checkpointFile(filePath string, device, inode uint64) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d\x00%d", filePath, device, inode)))
+	name := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' {
+			return '_'
+		}
+		return r
+	}, filepath.Base(filePath))
+	return name + "-" + hex.EncodeToString(sum[:8]) + ".json"
+}
+
+// Load returns the checkpoint of a file, if one was stored
+func (s *CheckpointStore) Load(filePath string, info os.FileInfo) (Checkpoint, bool) {
+	device, inode := fileID(info)
+	name := checkpointFile(filePath, device, inode)
+
+	s.mu.Lock()
+	checkpoint, pending := s.dirty[name]
+	s.mu.Unlock()
+	if pending {
+		return checkpoint, true
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, name))
+	if err != nil {
+		return Checkpoint{}, false
+	}
+	if err := json.Unmarshal(data, &checkpoint); err != nil || checkpoint.Path != filePath {
+		return Checkpoint{}, false
+	}
+	return checkpoint, true
+}
+
+// Update records the read position of a file. It is written on the next Flush.
+func (s *CheckpointStore) Update(filePath string, info os.FileInfo, offset int64) {
+	device, inode := fileID(info)
+	name := checkpointFile(filePath, device, inode)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dirty[name] = Checkpoint{
+		Path:    filePath,
+		Device:  device,
+		Inode:   inode,
+		Offset:  offset,
+		Updated: time.Now(),
+	}
+}
+
+// Flush writes the checkpoints updated since the last flush
+func (s *CheckpointStore) Flush() error {
+	s.mu.Lock()
+	dirty := s.dirty
+	s.dirty = make(map[string]Checkpoint)
+	s.mu.Unlock()
+
+	if len(dirty) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+
+	for name, checkpoint := range dirty {
+		data, err := json.Marshal(checkpoint)
+		if err != nil {
+			return err
+		}
+
+		// Write to a temporary file first so a failed write never leaves a broken checkpoint
+		path := filepath.Join(s.dir, name)
+		if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+			return err
+		}
+		if err := os.Rename(path+".tmp", path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package filereader
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Magic bytes of the supported compression formats
+var (
+	gzipMagic  = []byte{0x1f, 0x8b}
+	zstdMagic  = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	bzip2Magic = []byte("BZh")
+)
+
+// compressedReader is a decompressing reader that closes the underlying file
+type compressedReader struct {
+	io.Reader
+	decoder io.Closer // Optional, closed before the file
+	file    *os.File
+}
+
+func (r *compressedReader) Close() error {
+	if r.decoder != nil {
+		r.decoder.Close()
+	}
+	return r.file.Close()
+}
+
+// openFile opens a file for reading from offset, transparently decompressing
+// gzip, zstd and bzip2 content. The format is detected by its magic bytes, not
+// the file name. For compressed files offset is in the decompressed content.
+func openFile(filePath string, offset int64) (reader io.ReadCloser, compressed bool, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, false, err
+	}
+
+	buffered := bufio.NewReader(file)
+	magic, _ := buffered.Peek(4)
+
+	var r *compressedReader
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			file.Close()
+			return nil, false, fmt.Errorf("invalid gzip file: %w", err)
+		}
+		r = &compressedReader{Reader: gz, decoder: gz, file: file}
+
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(buffered)
+		if err != nil {
+			file.Close()
+			return nil, false, fmt.Errorf("invalid zstd file: %w", err)
+		}
+		r = &compressedReader{Reader: zr, decoder: zr.IOReadCloser(), file: file}
+
+	case bytes.HasPrefix(magic, bzip2Magic):
+		r = &compressedReader{Reader: bzip2.NewReader(buffered), file: file}
+
+	default:
+		if offset > 0 {
+			if _, err := file.Seek(offset, io.SeekStart); err != nil {
+				file.Close()
+				return nil, false, err
+			}
+			buffered.Reset(file)
+		}
+		return &compressedReader{Reader: buffered, file: file}, false, nil
+	}
+
+	// Compressed content cannot be seeked, skip the decompressed bytes instead
+	if offset > 0 {
+		if _, err := io.CopyN(io.Discard, r, offset); err != nil && err != io.EOF {
+			r.Close()
+			return nil, false, err
+		}
+	}
+	return r, true, nil
+}
+
+// isCompressed reports whether a file starts with the magic bytes of a supported compression format
+func isCompressed(filePath string) bool {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	magic := make([]byte, 4)
+	n, _ := io.ReadFull(file, magic)
+	magic = magic[:n]
+	return bytes.HasPrefix(magic, gzipMagic) || bytes.HasPrefix(magic, zstdMagic) || bytes.HasPrefix(magic, bzip2Magic)
+}
@@ -0,0 +1,16 @@
+//go:build unix
+
+package filereader
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileID returns the device and inode number of a file
+func fileID(info os.FileInfo) (device, inode uint64) {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return uint64(stat.Dev), uint64(stat.Ino)
+	}
+	return 0, 0
+}
@@ -3,44 +3,114 @@ package filereader
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/control-theory/gonzo/internal/multiline"
 	"github.com/fsnotify/fsnotify"
 )
 
+// maxLineSize is the longest line read in one piece, longer lines are split
+const maxLineSize = 1024 * 1024 // 1MB
+
+// checkpointInterval is how often checkpoints are written in follow mode
+const checkpointInterval = 5 * time.Second
+
+// Options configures a FileReader
+type Options struct {
+	Follow      bool             // Keep reading lines appended to the files, like tail -f
+	Multiline   multiline.Config // Joins multi-line records, assembled separately for every file
+	From        From             // Where reading the files starts (default FromStart)
+	TailLines   int              // Start this many lines before the end of every file, unless resuming from a checkpoint
+	Checkpoints *CheckpointStore // Records read positions, required for FromCheckpoint
+}
+
+// Line is a line (or multi-line record) read from a file
+type Line struct {
+	Text   string
+	Path   string // Absolute path of the file
+	Offset int64  // Byte offset of the line in the file (in the decompressed content for compressed files)
+}
+
 // FileReader manages reading from multiple files with optional follow mode
 type FileReader struct {
-	filePaths  []string
-	follow     bool
-	ctx        context.Context
-	cancel     context.CancelFunc
-	lineChan   chan string
-	wg         sync.WaitGroup
-	mu         sync.Mutex
-	watchers   map[string]*fsnotify.Watcher // Track file watchers for follow mode
-	fileStates map[string]*fileState        // Track file states for follow mode
+	filePaths    []string
+	patterns     []string // Absolute file patterns, re-expanded in follow mode
+	follow       bool
+	multiline    multiline.Config
+	flushTimeout time.Duration // How long a followed file's incomplete record waits for more lines
+	from         From
+	tailLines    int
+	checkpoints  *CheckpointStore // Optional
+	ctx          context.Context
+	cancel       context.CancelFunc
+	lineChan     chan Line
+	events       chan FileEvent
+	wg           sync.WaitGroup
+	tails        sync.WaitGroup // Watcher goroutines sending to lineChan in follow mode
+	mu           sync.Mutex
+	watchers     map[string]*fsnotify.Watcher // Track file watchers for follow mode
+	fileStates   map[string]*fileState        // Track file states for follow mode
+
+	// Glob watching for follow mode, only used by the follow goroutine
+	dirWatcher  *fsnotify.Watcher // Watches the directories new matches can appear in
+	watchedDirs map[string]bool
+	knownFiles  map[string]bool // Matches seen so far, followed or not
 }
 
 // fileState tracks the current position and state of a file being followed
 type fileState struct {
-	file     *os.File
-	scanner  *bufio.Scanner
-	size     int64
-	modified time.Time
+	mu         sync.Mutex // Serializes reads of the file and guards the fields below
+	file       *os.File
+	reader     *bufio.Reader
+	partial    []byte           // Last line of the file while it has no newline yet
+	offset     int64            // Offset of the next line
+	records    *recordAssembler // Multi-line records of the file
+	flushTimer *time.Timer      // Flushes an incomplete record when no more lines follow
+	closed     bool
+	info       os.FileInfo // Identity of the open file, to notice a replaced path
+	size       int64
+	modified   time.Time
+}
+
+// FileEvent reports a file that was added to or removed from follow mode
+type FileEvent struct {
+	Path    string
+	Removed bool
 }
 
 // New creates a new FileReader with the given file paths and options
-func New(filePaths []string, follow bool) (*FileReader, error) {
+func New(filePaths []string, opts Options) (*FileReader, error) {
 	if len(filePaths) == 0 {
 		return nil, fmt.Errorf("no file paths provided")
 	}
+	follow := opts.Follow
+
+	from, err := ParseFrom(string(opts.From))
+	if err != nil {
+		return nil, err
+	}
+	if from == FromCheckpoint && opts.Checkpoints == nil {
+		return nil, fmt.Errorf("reading from checkpoints needs a checkpoint store")
+	}
+
+	flushTimeout := opts.Multiline.Timeout
+	if opts.Multiline.Enabled() {
+		if _, err := multiline.NewAssembler(opts.Multiline); err != nil {
+			return nil, err
+		}
+		if flushTimeout <= 0 {
+			flushTimeout = multiline.DefaultTimeout
+		}
+	}
 
 	// Expand glob patterns
 	expandedPaths, err := expandGlobs(filePaths)
@@ -48,7 +118,10 @@ func New(filePaths []string, follow bool) (*FileReader, error) {
 		return nil, fmt.Errorf("error expanding file globs: %w", err)
 	}
 
-	if len(expandedPaths) == 0 {
+	// In follow mode, glob patterns may match files that do not exist yet
+	waitForFiles := follow && hasGlobPattern(filePaths)
+
+	if len(expandedPaths) == 0 && !waitForFiles {
 		return nil, fmt.Errorf("no files found matching the provided patterns")
 	}
 
@@ -62,20 +135,40 @@ func New(filePaths []string, follow bool) (*FileReader, error) {
 		}
 	}
 
-	if len(validPaths) == 0 {
+	if len(validPaths) == 0 && !waitForFiles {
 		return nil, fmt.Errorf("no valid readable files found")
 	}
 
+	// Read rotated files (app.log.2.gz, app.log.1) before the file they were rotated from
+	validPaths = orderRotatedChains(validPaths)
+
+	patterns := make([]string, 0, len(filePaths))
+	for _, pattern := range filePaths {
+		if absPattern, err := filepath.Abs(pattern); err == nil {
+			pattern = absPattern
+		}
+		patterns = append(patterns, pattern)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	fr := &FileReader{
-		filePaths:  validPaths,
-		follow:     follow,
-		ctx:        ctx,
-		cancel:     cancel,
-		lineChan:   make(chan string, 100),
-		watchers:   make(map[string]*fsnotify.Watcher),
-		fileStates: make(map[string]*fileState),
+		filePaths:    validPaths,
+		patterns:     patterns,
+		follow:       follow,
+		multiline:    opts.Multiline,
+		flushTimeout: flushTimeout,
+		from:         from,
+		tailLines:    opts.TailLines,
+		checkpoints:  opts.Checkpoints,
+		ctx:          ctx,
+		cancel:       cancel,
+		lineChan:     make(chan Line, 100),
+		events:       make(chan FileEvent, 100),
+		watchers:     make(map[string]*fsnotify.Watcher),
+		fileStates:   make(map[string]*fileState),
+		watchedDirs:  make(map[string]bool),
+		knownFiles:   make(map[string]bool),
 	}
 
 	return fr, nil
@@ -111,7 +204,7 @@ func expandGlobs(patterns []string) ([]string, error) {
 }
 
 // Start begins reading from the files
-func (fr *FileReader) Start() <-chan string {
+func (fr *FileReader) Start() <-chan Line {
 	if fr.follow {
 		fr.startFollowMode()
 	} else {
@@ -126,6 +219,7 @@ func (fr *FileReader) startReadMode() {
 	go func() {
 		defer fr.wg.Done()
 		defer close(fr.lineChan)
+		defer fr.flushCheckpoints()
 
 		for _, filePath := range fr.filePaths {
 			if err := fr.readFile(filePath); err != nil {
@@ -136,37 +230,79 @@ func (fr *FileReader) startReadMode() {
 	}()
 }
 
-// startFollowMode reads files and then watches for new content
+// startFollowMode reads files and then watches for new content and new files
 func (fr *FileReader) startFollowMode() {
 	fr.wg.Add(1)
 	go func() {
 		defer fr.wg.Done()
-		defer close(fr.lineChan)
-		defer fr.closeAllWatchers()
 
 		// First, read existing content of all files
 		for _, filePath := range fr.filePaths {
+			fr.knownFiles[filePath] = true
 			if err := fr.readFile(filePath); err != nil {
 				log.Printf("Error reading file %s: %v", filePath, err)
 				continue
 			}
 		}
 
-		// Then set up watchers for follow mode
+		// Then set up watchers for follow mode. Rotated and compressed files are
+		// history only and never grow, so only the live files are tailed.
 		for _, filePath := range fr.filePaths {
-			if err := fr.setupFileWatcher(filePath); err != nil {
+			if isRotated(filePath) || isCompressed(filePath) {
+				continue
+			}
+			if err := fr.setupFileWatcher(filePath, false); err != nil {
 				log.Printf("Error setting up watcher for %s: %v", filePath, err)
 			}
 		}
 
-		// Keep running until context is cancelled
-		<-fr.ctx.Done()
+		// Watch the pattern directories for files created from now on
+		if err := fr.startGlobWatcher(); err != nil {
+			log.Printf("Error watching directories for new files: %v", err)
+		}
+
+		// Keep running until context is cancelled, writing checkpoints as lines are read
+		var checkpointTicks <-chan time.Time
+		if fr.checkpoints != nil {
+			ticker := time.NewTicker(checkpointInterval)
+			defer ticker.Stop()
+			checkpointTicks = ticker.C
+		}
+	running:
+		for {
+			select {
+			case <-fr.ctx.Done():
+				break running
+			case <-checkpointTicks:
+				fr.flushCheckpoints()
+			}
+		}
+
+		fr.closeAllWatchers()
+		fr.tails.Wait()
+		fr.flushCheckpoints()
+		close(fr.lineChan)
 	}()
 }
 
-// readFile reads a file from beginning to end
+// readFile reads a file to its end, decompressing gzip, zstd and bzip2 files.
+// Reading starts as set by the From and TailLines options.
 func (fr *FileReader) readFile(filePath string) error {
-	file, err := os.Open(filePath)
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+	compressed := isCompressed(filePath)
+
+	start, tail, skip, err := fr.startOffset(filePath, info, compressed)
+	if err != nil {
+		return err
+	}
+	if skip {
+		return nil
+	}
+
+	file, _, err := openFile(filePath, start)
 	if err != nil {
 		return err
 	}
@@ -174,32 +310,84 @@ func (fr *FileReader) readFile(filePath string) error {
 
 	scanner := bufio.NewScanner(file)
 	// Set larger buffer size for long log lines
-	const maxScanTokenSize = 1024 * 1024 // 1MB
-	buf := make([]byte, maxScanTokenSize)
-	scanner.Buffer(buf, maxScanTokenSize)
+	buf := make([]byte, maxLineSize)
+	scanner.Buffer(buf, maxLineSize)
+
+	// Count the bytes consumed, including line endings, to know the offset of every line
+	offset, next := start, start
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		if advance == 0 && err == nil && len(data) >= maxLineSize {
+			// No line end within the buffer, split the line like the follow path does
+			advance, token = maxLineSize, data[:maxLineSize]
+		}
+		next += int64(advance)
+		return advance, token, err
+	})
+
+	records := newRecordAssembler(filePath, fr.multiline)
+	var ring *tailRing
+	if tail {
+		ring = newTailRing(fr.tailLines)
+	}
 
 	for scanner.Scan() {
-		select {
-		case <-fr.ctx.Done():
+		if ring != nil {
+			// Compressed file, only the last lines are kept
+			ring.add(Line{Text: scanner.Text(), Offset: offset})
+		} else if !fr.send(records.add(scanner.Text(), offset)) {
+			fr.updateCheckpoint(filePath, info, records.resumeOffset(offset))
 			return nil
-		case fr.lineChan <- scanner.Text():
+		}
+		offset = next
+	}
+	if ring != nil {
+		for _, line := range ring.all() {
+			if !fr.send(records.add(line.Text, line.Offset)) {
+				return nil
+			}
 		}
 	}
+	fr.send(records.flush())
+	fr.updateCheckpoint(filePath, info, offset)
 
 	return scanner.Err()
 }
 
-// setupFileWatcher sets up a file system watcher for follow mode
-func (fr *FileReader) setupFileWatcher(filePath string) error {
-	watcher, err := fsnotify.NewWatcher()
-	if err != nil {
-		return err
+// updateCheckpoint records the read position of a file when checkpoints are enabled
+func (fr *FileReader) updateCheckpoint(filePath string, info os.FileInfo, offset int64) {
+	if fr.checkpoints != nil && info != nil {
+		fr.checkpoints.Update(filePath, info, offset)
 	}
+}
 
-	// Get initial file info
-	info, err := os.Stat(filePath)
+// flushCheckpoints writes the recorded read positions
+func (fr *FileReader) flushCheckpoints() {
+	if fr.checkpoints == nil {
+		return
+	}
+	if err := fr.checkpoints.Flush(); err != nil {
+		log.Printf("Warning: failed to write file checkpoints: %v", err)
+	}
+}
+
+// send passes lines on, returning false once the reader is stopped
+func (fr *FileReader) send(lines []Line) bool {
+	for _, line := range lines {
+		select {
+		case <-fr.ctx.Done():
+			return false
+		case fr.lineChan <- line:
+		}
+	}
+	return true
+}
+
+// setupFileWatcher sets up a file system watcher for follow mode. The file is
+// tailed from its end, or from its beginning for files that appeared while following.
+func (fr *FileReader) setupFileWatcher(filePath string, fromStart bool) error {
+	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
-		watcher.Close()
 		return err
 	}
 
@@ -210,25 +398,34 @@ func (fr *FileReader) setupFileWatcher(filePath string) error {
 		return err
 	}
 
-	// Seek to end of file
-	currentSize, err := file.Seek(0, io.SeekEnd)
+	// Get initial file info
+	info, err := file.Stat()
 	if err != nil {
 		file.Close()
 		watcher.Close()
 		return err
 	}
 
-	scanner := bufio.NewScanner(file)
-	const maxScanTokenSize = 1024 * 1024 // 1MB
-	buf := make([]byte, maxScanTokenSize)
-	scanner.Buffer(buf, maxScanTokenSize)
+	// Seek to end of file
+	var currentSize int64
+	if !fromStart {
+		currentSize, err = file.Seek(0, io.SeekEnd)
+		if err != nil {
+			file.Close()
+			watcher.Close()
+			return err
+		}
+	}
 
 	// Store file state
 	fr.mu.Lock()
 	fr.watchers[filePath] = watcher
 	fr.fileStates[filePath] = &fileState{
 		file:     file,
-		scanner:  scanner,
+		reader:   bufio.NewReaderSize(file, 64*1024),
+		offset:   currentSize,
+		records:  newRecordAssembler(filePath, fr.multiline),
+		info:     info,
 		size:     currentSize,
 		modified: info.ModTime(),
 	}
@@ -241,15 +438,20 @@ func (fr *FileReader) setupFileWatcher(filePath string) error {
 	}
 
 	// Start watching for changes
-	fr.wg.Add(1)
+	fr.tails.Add(1)
 	go fr.watchFile(filePath, watcher)
 
+	if fromStart {
+		// Read what was written before the watcher was added
+		fr.handleFileWrite(filePath)
+	}
+
 	return nil
 }
 
 // watchFile watches a single file for changes
 func (fr *FileReader) watchFile(filePath string, watcher *fsnotify.Watcher) {
-	defer fr.wg.Done()
+	defer fr.tails.Done()
 
 	for {
 		select {
@@ -284,6 +486,13 @@ func (fr *FileReader) handleFileWrite(filePath string) {
 		return
 	}
 
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if state.closed {
+		return
+	}
+
 	// Check if file was truncated (common with log rotation)
 	info, err := os.Stat(filePath)
 	if err != nil {
@@ -292,40 +501,73 @@ func (fr *FileReader) handleFileWrite(filePath string) {
 
 	if info.Size() < state.size {
 		// File was truncated, reopen from beginning
-		fr.reopenFile(filePath)
-		return
+		fr.send(state.records.flush())
+		fr.reopenFile(filePath, state)
 	}
 
 	// Read new lines
-	for state.scanner.Scan() {
-		select {
-		case <-fr.ctx.Done():
-			return
-		case fr.lineChan <- state.scanner.Text():
-		}
-	}
+	fr.readNewLines(state)
 
 	// Update file state
-	fr.mu.Lock()
 	if state.file != nil {
 		if pos, err := state.file.Seek(0, io.SeekCurrent); err == nil {
 			state.size = pos
 		}
 		state.modified = info.ModTime()
 	}
-	fr.mu.Unlock()
 }
 
-// reopenFile reopens a file that may have been rotated
-func (fr *FileReader) reopenFile(filePath string) {
-	fr.mu.Lock()
-	defer fr.mu.Unlock()
-
-	state, exists := fr.fileStates[filePath]
-	if !exists {
+// readNewLines sends the lines appended to a followed file since the last read.
+// A last line without a newline is kept until a later write completes it. The
+// caller holds state.mu.
+func (fr *FileReader) readNewLines(state *fileState) {
+	if state.reader == nil {
 		return
 	}
 
+	for {
+		chunk, err := state.reader.ReadSlice('\n')
+		state.partial = append(state.partial, chunk...)
+		if errors.Is(err, bufio.ErrBufferFull) && len(state.partial) < maxLineSize {
+			continue
+		}
+		if err != nil && !errors.Is(err, bufio.ErrBufferFull) {
+			break // EOF, wait for the next write
+		}
+
+		line := strings.TrimSuffix(strings.TrimSuffix(string(state.partial), "\n"), "\r")
+		offset := state.offset
+		state.offset += int64(len(state.partial))
+		state.partial = state.partial[:0]
+		if !fr.send(state.records.add(line, offset)) {
+			return
+		}
+	}
+
+	fr.updateCheckpoint(state.records.path, state.info, state.records.resumeOffset(state.offset))
+
+	// Flush an incomplete multi-line record unless more lines arrive in time
+	if state.records.pending() {
+		if state.flushTimer == nil {
+			state.flushTimer = time.AfterFunc(fr.flushTimeout, func() { fr.flushRecord(state) })
+		} else {
+			state.flushTimer.Reset(fr.flushTimeout)
+		}
+	}
+}
+
+// flushRecord sends the incomplete multi-line record of a followed file
+func (fr *FileReader) flushRecord(state *fileState) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if !state.closed {
+		fr.send(state.records.flush())
+	}
+}
+
+// reopenFile reopens a file that may have been rotated. The caller holds state.mu.
+func (fr *FileReader) reopenFile(filePath string, state *fileState) {
 	// Close old file
 	if state.file != nil {
 		state.file.Close()
@@ -338,16 +580,15 @@ func (fr *FileReader) reopenFile(filePath string) {
 		return
 	}
 
-	// Create new scanner
-	scanner := bufio.NewScanner(file)
-	const maxScanTokenSize = 1024 * 1024 // 1MB
-	buf := make([]byte, maxScanTokenSize)
-	scanner.Buffer(buf, maxScanTokenSize)
-
 	// Update state
 	state.file = file
-	state.scanner = scanner
+	state.reader = bufio.NewReaderSize(file, 64*1024)
+	state.partial = state.partial[:0]
+	state.offset = 0
 	state.size = 0
+	if info, err := file.Stat(); err == nil {
+		state.info = info
+	}
 
 	log.Printf("Reopened file %s (likely rotated)", filePath)
 }
@@ -355,29 +596,47 @@ func (fr *FileReader) reopenFile(filePath string) {
 // cleanupFile closes and removes tracking for a file
 func (fr *FileReader) cleanupFile(filePath string) {
 	fr.mu.Lock()
-	defer fr.mu.Unlock()
+	state, exists := fr.fileStates[filePath]
+	delete(fr.fileStates, filePath)
+	watcher, watched := fr.watchers[filePath]
+	delete(fr.watchers, filePath)
+	fr.mu.Unlock()
+
+	if watched {
+		watcher.Close()
+	}
 
-	if state, exists := fr.fileStates[filePath]; exists {
+	if exists {
+		// Wait for a running read or flush, which lock the state before the file
+		state.mu.Lock()
+		state.closed = true
+		if state.flushTimer != nil {
+			state.flushTimer.Stop()
+		}
 		if state.file != nil {
 			state.file.Close()
 		}
-		delete(fr.fileStates, filePath)
-	}
-
-	if watcher, exists := fr.watchers[filePath]; exists {
-		watcher.Close()
-		delete(fr.watchers, filePath)
+		state.mu.Unlock()
 	}
 }
 
-// closeAllWatchers closes all file watchers
+// closeAllWatchers closes all file and directory watchers
 func (fr *FileReader) closeAllWatchers() {
 	fr.mu.Lock()
-	defer fr.mu.Unlock()
-
+	filePaths := make([]string, 0, len(fr.watchers))
 	for filePath := range fr.watchers {
+		filePaths = append(filePaths, filePath)
+	}
+	fr.mu.Unlock()
+
+	// cleanupFile takes the lock itself
+	for _, filePath := range filePaths {
 		fr.cleanupFile(filePath)
 	}
+
+	if fr.dirWatcher != nil {
+		fr.dirWatcher.Close()
+	}
 }
 
 // Stop stops the file reader and closes all resources
@@ -390,6 +649,12 @@ func (fr *FileReader) Wait() {
 	fr.wg.Wait()
 }
 
+// Events returns the channel reporting files added to or removed from follow
+// mode. Events are dropped when nobody keeps up with reading them.
+func (fr *FileReader) Events() <-chan FileEvent {
+	return fr.events
+}
+
 // GetFilePaths returns the list of files being read
 func (fr *FileReader) GetFilePaths() []string {
 	return append([]string{}, fr.filePaths...)
@@ -0,0 +1,128 @@
+package filereader
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+)
+
+// From is where reading a file starts
+type From string
+
+const (
+	FromStart      From = "start"      // Read files from their beginning
+	FromEnd        From = "end"        // Only read lines written from now on
+	FromCheckpoint From = "checkpoint" // Resume where the previous run stopped, or start at the beginning
+)
+
+// ParseFrom parses a --from value; empty means FromStart
+func ParseFrom(value string) (From, error) {
+	switch from := From(value); from {
+	case "":
+		return FromStart, nil
+	case FromStart, FromEnd, FromCheckpoint:
+		return from, nil
+	default:
+		return "", fmt.Errorf("invalid --from value %q (use start, end or checkpoint)", value)
+	}
+}
+
+// startOffset returns the offset reading a file starts at. tail reports that
+// the file is compressed and TailLines lines before its end have to be found
+// while reading it. skip reports that nothing of the file is to be read.
+func (fr *FileReader) startOffset(filePath string, info os.FileInfo, compressed bool) (offset int64, tail, skip bool, err error) {
+	if fr.from == FromCheckpoint && fr.checkpoints != nil {
+		// A plain file shorter than its checkpoint was truncated, start over
+		if checkpoint, ok := fr.checkpoints.Load(filePath, info); ok && (compressed || checkpoint.Offset <= info.Size()) {
+			return checkpoint.Offset, false, false, nil
+		}
+	}
+
+	if fr.tailLines > 0 {
+		if compressed {
+			return 0, true, false, nil
+		}
+		offset, err := tailOffset(filePath, info.Size(), fr.tailLines)
+		return offset, false, false, err
+	}
+
+	if fr.from == FromEnd {
+		if compressed {
+			// Compressed files never grow, there is nothing after their end
+			return 0, false, true, nil
+		}
+		return info.Size(), false, false, nil
+	}
+
+	return 0, false, false, nil
+}
+
+// tailOffset returns the offset of the n-th line before the end of a file by
+// reading it backwards, so that huge files are not read in full
+func tailOffset(filePath string, size int64, n int) (int64, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	const blockSize = 64 * 1024
+	buf := make([]byte, blockSize)
+	end := size
+	newlines := 0
+
+	for end > 0 {
+		start := max(end-blockSize, 0)
+		block := buf[:end-start]
+		if _, err := file.ReadAt(block, start); err != nil && err != io.EOF {
+			return 0, err
+		}
+
+		// The newline ending the last line does not start another line
+		if end == size && len(block) > 0 && block[len(block)-1] == '\n' {
+			block = block[:len(block)-1]
+		}
+
+		for i := len(block); i > 0; {
+			i = bytes.LastIndexByte(block[:i], '\n')
+			if i < 0 {
+				break
+			}
+			newlines++
+			if newlines == n {
+				return start + int64(i) + 1, nil
+			}
+		}
+		end = start
+	}
+
+	return 0, nil // The file has no more than n lines
+}
+
+// tailRing keeps the last lines read from a compressed file
+type tailRing struct {
+	lines []Line
+	next  int
+	full  bool
+}
+
+func newTailRing(n int) *tailRing {
+	return &tailRing{lines: make([]Line, n)}
+}
+
+func (r *tailRing) add(line Line) {
+	r.lines[r.next] = line
+	r.next = (r.next + 1) % len(r.lines)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// all returns the kept lines, oldest first
+func (r *tailRing) all() []Line {
+	if !r.full {
+		return r.lines[:r.next]
+	}
+	return append(append([]Line{}, r.lines[r.next:]...), r.lines[:r.next]...)
+}
@@ -0,0 +1,82 @@
+package filereader
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// compressionExtensions are stripped from file names before looking for a rotation index
+var compressionExtensions = []string{".gz", ".zst", ".bz2"}
+
+// rotationIndex splits a rotated file name like app.log.2.gz into its live file
+// name (app.log) and rotation index (2). Files that are not rotated have index 0.
+// Uncompressed names such as server.8080 only count as rotated when the live
+// file exists next to them.
+func rotationIndex(filePath string) (base string, index int) {
+	name := filePath
+	compressed := false
+	for _, ext := range compressionExtensions {
+		if strings.HasSuffix(name, ext) {
+			name = strings.TrimSuffix(name, ext)
+			compressed = true
+			break
+		}
+	}
+
+	dot := strings.LastIndexByte(name, '.')
+	if dot <= 0 || dot == len(name)-1 {
+		return filePath, 0
+	}
+	n, err := strconv.Atoi(name[dot+1:])
+	if err != nil || n <= 0 || strings.ContainsAny(name[dot+1:], "+-") {
+		return filePath, 0
+	}
+	if !compressed {
+		if _, err := os.Stat(name[:dot]); err != nil {
+			return filePath, 0
+		}
+	}
+	return name[:dot], n
+}
+
+// orderRotatedChains orders files so that every rotated chain is read oldest
+// first: app.log.3.gz, app.log.2.gz, app.log.1, app.log. Chains keep the
+// sorted order of their live file names.
+func orderRotatedChains(filePaths []string) []string {
+	type chainFile struct {
+		path  string
+		base  string
+		index int
+	}
+
+	files := make([]chainFile, 0, len(filePaths))
+	for _, path := range filePaths {
+		base, index := rotationIndex(path)
+		files = append(files, chainFile{path: path, base: base, index: index})
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		if files[i].base != files[j].base {
+			return files[i].base < files[j].base
+		}
+		// The live file (index 0) goes last, higher indexes are older
+		if (files[i].index == 0) != (files[j].index == 0) {
+			return files[j].index == 0
+		}
+		return files[i].index > files[j].index
+	})
+
+	ordered := make([]string, len(files))
+	for i, file := range files {
+		ordered[i] = file.path
+	}
+	return ordered
+}
+
+// isRotated reports whether a file is a rotated (no longer written) part of a chain
+func isRotated(filePath string) bool {
+	_, index := rotationIndex(filePath)
+	return index > 0
+}
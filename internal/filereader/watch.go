@@ -0,0 +1,229 @@
+package filereader
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// hasGlobMeta reports whether a path contains glob wildcards
+func hasGlobMeta(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// hasGlobPattern reports whether any of the patterns contains glob wildcards
+func hasGlobPattern(patterns []string) bool {
+	for _, pattern := range patterns {
+		if hasGlobMeta(pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// startGlobWatcher watches the directories of the file patterns, so that files
+// created while following are picked up and deleted files are let go of
+func (fr *FileReader) startGlobWatcher() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	fr.dirWatcher = watcher
+
+	fr.watchPatternDirs()
+
+	// Pick up files created between the initial glob expansion and now
+	fr.rescan()
+
+	fr.tails.Add(1)
+	go fr.watchDirectories()
+
+	return nil
+}
+
+// watchPatternDirs adds the directories new matches can appear in to the
+// directory watcher. For /var/log/pods/*/*.log these are all /var/log/pods/*
+// directories, plus /var/log/pods itself to notice new pod directories.
+func (fr *FileReader) watchPatternDirs() {
+	for _, pattern := range fr.patterns {
+		for dir := filepath.Dir(pattern); ; dir = filepath.Dir(dir) {
+			matches, _ := filepath.Glob(dir)
+			for _, match := range matches {
+				if fr.watchedDirs[match] {
+					continue
+				}
+				if info, err := os.Stat(match); err != nil || !info.IsDir() {
+					continue
+				}
+				if err := fr.dirWatcher.Add(match); err != nil {
+					log.Printf("Warning: cannot watch directory %s: %v", match, err)
+					continue
+				}
+				fr.watchedDirs[match] = true
+			}
+
+			// Stop at the first directory without wildcards
+			if !hasGlobMeta(dir) || dir == filepath.Dir(dir) {
+				break
+			}
+		}
+	}
+}
+
+// watchDirectories rescans the file patterns whenever files are created,
+// removed or renamed in one of the watched directories
+func (fr *FileReader) watchDirectories() {
+	defer fr.tails.Done()
+
+	for {
+		select {
+		case <-fr.ctx.Done():
+			return
+
+		case event, ok := <-fr.dirWatcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
+				continue
+			}
+			if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 && fr.watchedDirs[event.Name] {
+				// A watched directory is gone, watch it again should it come back
+				fr.dirWatcher.Remove(event.Name)
+				delete(fr.watchedDirs, event.Name)
+			}
+
+			// Coalesce bursts of events (e.g. many files created at once) into one rescan
+			fr.drainDirEvents()
+			fr.watchPatternDirs()
+			fr.rescan()
+
+		case err, ok := <-fr.dirWatcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("Directory watcher error: %v", err)
+		}
+	}
+}
+
+// drainDirEvents discards pending directory events, noting removed directories
+func (fr *FileReader) drainDirEvents() {
+	for {
+		select {
+		case event, ok := <-fr.dirWatcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 && fr.watchedDirs[event.Name] {
+				fr.dirWatcher.Remove(event.Name)
+				delete(fr.watchedDirs, event.Name)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// rescan expands the file patterns again. New live files are followed from
+// their beginning, followed files that no longer exist are dropped, and a path
+// that now refers to another file (rotation by rename) is read anew.
+func (fr *FileReader) rescan() {
+	filePaths, err := expandGlobs(fr.patterns)
+	if err != nil {
+		return
+	}
+
+	current := make(map[string]bool, len(filePaths))
+	for _, filePath := range filePaths {
+		current[filePath] = true
+	}
+
+	for filePath := range fr.knownFiles {
+		if current[filePath] {
+			continue
+		}
+		delete(fr.knownFiles, filePath)
+		if fr.isFollowed(filePath) {
+			fr.stopFollowing(filePath)
+			fr.notify(FileEvent{Path: filePath, Removed: true})
+		}
+	}
+
+	for _, filePath := range orderRotatedChains(filePaths) {
+		info, err := os.Stat(filePath)
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		if fr.knownFiles[filePath] {
+			fr.mu.Lock()
+			state, followed := fr.fileStates[filePath]
+			fr.mu.Unlock()
+			if !followed || fr.isSameFile(state, info) {
+				continue
+			}
+			// Same name, different file: follow the new one from its beginning
+			fr.stopFollowing(filePath)
+			if err := fr.setupFileWatcher(filePath, true); err != nil {
+				log.Printf("Error setting up watcher for %s: %v", filePath, err)
+			}
+			continue
+		}
+
+		fr.knownFiles[filePath] = true
+		if isRotated(filePath) || isCompressed(filePath) {
+			// History appearing next to a followed file, its lines were read already
+			continue
+		}
+		if err := fr.setupFileWatcher(filePath, true); err != nil {
+			log.Printf("Error setting up watcher for %s: %v", filePath, err)
+			continue
+		}
+		fr.notify(FileEvent{Path: filePath})
+	}
+}
+
+// isFollowed reports whether a file is being tailed
+func (fr *FileReader) isFollowed(filePath string) bool {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	_, exists := fr.fileStates[filePath]
+	return exists
+}
+
+// isSameFile reports whether a followed file is still the file at its path,
+// or its identity is not known
+func (fr *FileReader) isSameFile(state *fileState, info os.FileInfo) bool {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return state.info == nil || os.SameFile(state.info, info)
+}
+
+// stopFollowing reads the remaining lines and record of a followed file and stops tailing it
+func (fr *FileReader) stopFollowing(filePath string) {
+	fr.mu.Lock()
+	state, exists := fr.fileStates[filePath]
+	fr.mu.Unlock()
+
+	if exists {
+		state.mu.Lock()
+		if !state.closed {
+			fr.readNewLines(state)
+			fr.send(state.records.flush())
+		}
+		state.mu.Unlock()
+	}
+	fr.cleanupFile(filePath)
+}
+
+// notify reports a file event without blocking the reader
+func (fr *FileReader) notify(event FileEvent) {
+	select {
+	case fr.events <- event:
+	default:
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/control-theory/gonzo/internal/multiline"
+
 	"gopkg.in/yaml.v3"
 )
 
@@ -34,14 +36,15 @@ type BatchConfig struct {
 
 // Format represents a custom log format definition
 type Format struct {
-	Name        string        `yaml:"name"`
-	Description string        `yaml:"description,omitempty"`
-	Author      string        `yaml:"author,omitempty"`
-	Type        string        `yaml:"type"` // "text", "json", "structured"
-	Pattern     PatternConfig `yaml:"pattern,omitempty"`
-	JSON        JSONConfig    `yaml:"json,omitempty"`
-	Batch       BatchConfig   `yaml:"batch,omitempty"`
-	Mapping     FieldMapping  `yaml:"mapping"`
+	Name        string           `yaml:"name"`
+	Description string           `yaml:"description,omitempty"`
+	Author      string           `yaml:"author,omitempty"`
+	Type        string           `yaml:"type"` // "text", "json", "structured"
+	Pattern     PatternConfig    `yaml:"pattern,omitempty"`
+	JSON        JSONConfig       `yaml:"json,omitempty"`
+	Batch       BatchConfig      `yaml:"batch,omitempty"`
+	Multiline   multiline.Config `yaml:"multiline,omitempty"`
+	Mapping     FieldMapping     `yaml:"mapping"`
 }
 
 // PatternConfig defines patterns for text-based log parsing
@@ -421,7 +424,7 @@ func (p *Parser) ParseTimestamp(value interface{}, format string) (time.Time, er
 		if err != nil {
 			return time.Time{}, err
 		}
-		return time.Unix(0, ns), nil	
+		return time.Unix(0, ns), nil
 	case "rfc3339":
 		return time.Parse(time.RFC3339, valueStr)
 	default:
@@ -0,0 +1,235 @@
+package k8s
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Pod is the subset of the Kubernetes Pod object gonzo needs
+type Pod struct {
+	Metadata struct {
+		Name            string            `json:"name"`
+		Namespace       string            `json:"namespace"`
+		UID             string            `json:"uid"`
+		Labels          map[string]string `json:"labels"`
+		ResourceVersion string            `json:"resourceVersion"`
+	} `json:"metadata"`
+	Spec struct {
+		NodeName string `json:"nodeName"`
+	} `json:"spec"`
+	Status struct {
+		Phase             string            `json:"phase"`
+		ContainerStatuses []ContainerStatus `json:"containerStatuses"`
+	} `json:"status"`
+}
+
+// ContainerStatus is the state of one container of a pod
+type ContainerStatus struct {
+	Name         string `json:"name"`
+	ContainerID  string `json:"containerID"`
+	RestartCount int    `json:"restartCount"`
+	State        struct {
+		Running *struct {
+			StartedAt time.Time `json:"startedAt"`
+		} `json:"running"`
+	} `json:"state"`
+}
+
+// PodEvent is a change reported by a pod watch
+type PodEvent struct {
+	Type   string // ADDED, MODIFIED or DELETED
+	Object Pod
+}
+
+// LogOptions selects which part of a container log to stream
+type LogOptions struct {
+	TailLines int       // Lines from the end of the log to start with (negative = whole log)
+	SinceTime time.Time // Only return lines newer than this time (takes precedence over TailLines)
+}
+
+// errWatchExpired is returned when the watch resource version is too old and pods must be listed again
+var errWatchExpired = errors.New("watch expired")
+
+// Client is a minimal Kubernetes API client for following pod logs
+type Client struct {
+	config *Config
+	http   *http.Client
+}
+
+// NewClient creates a client for the API server described by config
+func NewClient(config *Config) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config.tlsConfig
+
+	return &Client{
+		config: config,
+		http: &http.Client{
+			Transport: transport,
+			Timeout:   0, // No timeout for streaming, rely on ctx cancellation
+		},
+	}
+}
+
+// ListPods lists the pods in namespace (empty = all namespaces) matching the label
+// selector and returns the resource version to start a watch from
+func (c *Client) ListPods(ctx context.Context, namespace, selector string) ([]Pod, string, error) {
+	query := url.Values{}
+	if selector != "" {
+		query.Set("labelSelector", selector)
+	}
+
+	resp, err := c.get(ctx, podsPath(namespace), query)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	var list struct {
+		Metadata struct {
+			ResourceVersion string `json:"resourceVersion"`
+		} `json:"metadata"`
+		Items []Pod `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, "", fmt.Errorf("failed to decode pod list: %w", err)
+	}
+
+	return list.Items, list.Metadata.ResourceVersion, nil
+}
+
+// WatchPods streams pod changes after resourceVersion to onEvent until the server
+// closes the watch, ctx is cancelled or onEvent returns an error
+func (c *Client) WatchPods(ctx context.Context, namespace, selector, resourceVersion string, onEvent func(PodEvent) error) error {
+	query := url.Values{}
+	query.Set("watch", "true")
+	query.Set("resourceVersion", resourceVersion)
+	query.Set("timeoutSeconds", "300")
+	if selector != "" {
+		query.Set("labelSelector", selector)
+	}
+
+	resp, err := c.get(ctx, podsPath(namespace), query)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var event struct {
+			Type   string          `json:"type"`
+			Object json.RawMessage `json:"object"`
+		}
+		if err := decoder.Decode(&event); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil // Watch timed out on the server side
+			}
+			return err
+		}
+
+		switch event.Type {
+		case "ADDED", "MODIFIED", "DELETED":
+			var pod Pod
+			if err := json.Unmarshal(event.Object, &pod); err != nil {
+				return fmt.Errorf("failed to decode pod event: %w", err)
+			}
+			if err := onEvent(PodEvent{Type: event.Type, Object: pod}); err != nil {
+				return err
+			}
+		case "ERROR":
+			var status struct {
+				Code    int    `json:"code"`
+				Message string `json:"message"`
+			}
+			json.Unmarshal(event.Object, &status)
+			if status.Code == http.StatusGone {
+				return errWatchExpired
+			}
+			return fmt.Errorf("watch failed: %s", status.Message)
+		}
+	}
+}
+
+// StreamLogs follows the log of a container and calls onLine for every line.
+// Lines are prefixed with their RFC3339 timestamp as returned by the API server.
+func (c *Client) StreamLogs(ctx context.Context, namespace, pod, container string, opts LogOptions, onLine func(string) error) error {
+	query := url.Values{}
+	query.Set("container", container)
+	query.Set("follow", "true")
+	query.Set("timestamps", "true")
+	if !opts.SinceTime.IsZero() {
+		query.Set("sinceTime", opts.SinceTime.UTC().Format(time.RFC3339))
+	} else if opts.TailLines >= 0 {
+		query.Set("tailLines", strconv.Itoa(opts.TailLines))
+	}
+
+	path := "/api/v1/namespaces/" + url.PathEscape(namespace) + "/pods/" + url.PathEscape(pod) + "/log"
+	resp, err := c.get(ctx, path, query)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+
+	// Set larger buffer size to handle long JSON lines
+	const maxScanTokenSize = 1024 * 1024 // 1MB
+	buf := make([]byte, maxScanTokenSize)
+	scanner.Buffer(buf, maxScanTokenSize)
+
+	for scanner.Scan() {
+		if err := onLine(scanner.Text()); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// get performs an authorized GET request and checks the response status
+func (c *Client) get(ctx context.Context, path string, query url.Values) (*http.Response, error) {
+	endpoint := c.config.Server + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if err := c.config.authorize(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 8192))
+		if resp.StatusCode == http.StatusGone {
+			return nil, errWatchExpired
+		}
+		return nil, errors.New("kubernetes API request failed: " + resp.Status + " - " + string(b))
+	}
+
+	return resp, nil
+}
+
+func podsPath(namespace string) string {
+	if namespace == "" {
+		return "/api/v1/pods"
+	}
+	return "/api/v1/namespaces/" + url.PathEscape(namespace) + "/pods"
+}
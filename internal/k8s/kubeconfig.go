@@ -0,0 +1,389 @@
+package k8s
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// In-cluster service account locations
+const (
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+)
+
+// Config holds what is needed to talk to a Kubernetes API server
+type Config struct {
+	Server    string // API server URL
+	Namespace string // Default namespace of the selected context
+
+	Username string
+	Password string
+
+	tlsConfig *tls.Config
+	token     func() (string, error)
+}
+
+// kubeconfig mirrors the parts of a kubeconfig file gonzo understands
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+			TLSServerName            string `yaml:"tls-server-name"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []struct {
+		Name string   `yaml:"name"`
+		User authInfo `yaml:"user"`
+	} `yaml:"users"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster   string `yaml:"cluster"`
+			User      string `yaml:"user"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+
+	dir string // Directory of the file, relative paths are resolved against it
+}
+
+// authInfo holds the credentials of a kubeconfig user
+type authInfo struct {
+	ClientCertificate     string      `yaml:"client-certificate"`
+	ClientCertificateData string      `yaml:"client-certificate-data"`
+	ClientKey             string      `yaml:"client-key"`
+	ClientKeyData         string      `yaml:"client-key-data"`
+	Token                 string      `yaml:"token"`
+	TokenFile             string      `yaml:"tokenFile"`
+	Username              string      `yaml:"username"`
+	Password              string      `yaml:"password"`
+	Exec                  *execConfig `yaml:"exec"`
+}
+
+// execConfig describes a client-go credential plugin (used by EKS, GKE, AKS, ...)
+type execConfig struct {
+	Command    string   `yaml:"command"`
+	Args       []string `yaml:"args"`
+	APIVersion string   `yaml:"apiVersion"`
+	Env        []struct {
+		Name  string `yaml:"name"`
+		Value string `yaml:"value"`
+	} `yaml:"env"`
+}
+
+// LoadConfig builds a Config from a kubeconfig file. When path is empty the
+// KUBECONFIG environment variable and ~/.kube/config are tried, falling back to
+// the in-cluster service account when gonzo runs inside a pod. contextName
+// overrides the current context of the kubeconfig.
+func LoadConfig(path, contextName string) (*Config, error) {
+	paths := kubeconfigPaths(path)
+
+	var files []*kubeconfig
+	for _, p := range paths {
+		kc, err := readKubeconfig(p)
+		if err != nil {
+			if os.IsNotExist(err) && path == "" {
+				continue
+			}
+			return nil, err
+		}
+		files = append(files, kc)
+	}
+
+	if len(files) == 0 {
+		if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+			return inClusterConfig()
+		}
+		return nil, fmt.Errorf("no kubeconfig found (tried %s)", strings.Join(paths, ", "))
+	}
+
+	return configFromKubeconfig(files, contextName)
+}
+
+// kubeconfigPaths returns the kubeconfig files to read, in order of precedence
+func kubeconfigPaths(path string) []string {
+	if path != "" {
+		return []string{path}
+	}
+	if env := os.Getenv("KUBECONFIG"); env != "" {
+		var paths []string
+		for _, p := range filepath.SplitList(env) {
+			if p != "" {
+				paths = append(paths, p)
+			}
+		}
+		return paths
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	return []string{filepath.Join(home, ".kube", "config")}
+}
+
+func readKubeconfig(path string) (*kubeconfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var kc kubeconfig
+	if err := yaml.Unmarshal(data, &kc); err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig %s: %w", path, err)
+	}
+	kc.dir = filepath.Dir(path)
+	return &kc, nil
+}
+
+// configFromKubeconfig resolves the context across all files; like kubectl, the
+// first file defining a name wins
+func configFromKubeconfig(files []*kubeconfig, contextName string) (*Config, error) {
+	if contextName == "" {
+		for _, kc := range files {
+			if kc.CurrentContext != "" {
+				contextName = kc.CurrentContext
+				break
+			}
+		}
+	}
+	if contextName == "" {
+		return nil, fmt.Errorf("kubeconfig has no current context, pass --k8s-context")
+	}
+
+	var clusterName, userName, namespace string
+	found := false
+	for _, kc := range files {
+		for _, c := range kc.Contexts {
+			if c.Name == contextName && !found {
+				clusterName, userName, namespace = c.Context.Cluster, c.Context.User, c.Context.Namespace
+				found = true
+			}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("context %q not found in kubeconfig", contextName)
+	}
+
+	cfg := &Config{Namespace: namespace}
+	tlsConfig := &tls.Config{}
+
+	clusterFound := false
+	for _, kc := range files {
+		for _, c := range kc.Clusters {
+			if c.Name != clusterName || clusterFound {
+				continue
+			}
+			clusterFound = true
+			cfg.Server = strings.TrimSuffix(c.Cluster.Server, "/")
+			tlsConfig.InsecureSkipVerify = c.Cluster.InsecureSkipTLSVerify
+			tlsConfig.ServerName = c.Cluster.TLSServerName
+
+			caData, err := dataOrFile(c.Cluster.CertificateAuthorityData, c.Cluster.CertificateAuthority, kc.dir)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read certificate authority: %w", err)
+			}
+			if len(caData) > 0 {
+				pool := x509.NewCertPool()
+				if !pool.AppendCertsFromPEM(caData) {
+					return nil, fmt.Errorf("invalid certificate authority for cluster %q", clusterName)
+				}
+				tlsConfig.RootCAs = pool
+			}
+		}
+	}
+	if !clusterFound {
+		return nil, fmt.Errorf("cluster %q not found in kubeconfig", clusterName)
+	}
+	if cfg.Server == "" {
+		return nil, fmt.Errorf("cluster %q has no server", clusterName)
+	}
+
+	userFound := false
+	for _, kc := range files {
+		for _, u := range kc.Users {
+			if u.Name != userName || userFound {
+				continue
+			}
+			userFound = true
+			if err := cfg.applyAuth(u.User, kc.dir, tlsConfig); err != nil {
+				return nil, fmt.Errorf("user %q: %w", userName, err)
+			}
+		}
+	}
+
+	cfg.tlsConfig = tlsConfig
+	return cfg, nil
+}
+
+// applyAuth configures the credentials of a kubeconfig user
+func (c *Config) applyAuth(user authInfo, dir string, tlsConfig *tls.Config) error {
+	certData, err := dataOrFile(user.ClientCertificateData, user.ClientCertificate, dir)
+	if err != nil {
+		return fmt.Errorf("failed to read client certificate: %w", err)
+	}
+	keyData, err := dataOrFile(user.ClientKeyData, user.ClientKey, dir)
+	if err != nil {
+		return fmt.Errorf("failed to read client key: %w", err)
+	}
+	if len(certData) > 0 && len(keyData) > 0 {
+		cert, err := tls.X509KeyPair(certData, keyData)
+		if err != nil {
+			return fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	switch {
+	case user.Token != "":
+		token := user.Token
+		c.token = func() (string, error) { return token, nil }
+	case user.TokenFile != "":
+		tokenFile := resolvePath(user.TokenFile, dir)
+		c.token = func() (string, error) { return readToken(tokenFile) }
+	case user.Exec != nil:
+		c.token = newExecTokenSource(user.Exec)
+	}
+
+	c.Username = user.Username
+	c.Password = user.Password
+	return nil
+}
+
+// inClusterConfig uses the service account mounted into the pod
+func inClusterConfig() (*Config, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if port == "" {
+		port = "443"
+	}
+
+	tlsConfig := &tls.Config{}
+	if caData, err := os.ReadFile(filepath.Join(serviceAccountDir, "ca.crt")); err == nil {
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM(caData)
+		tlsConfig.RootCAs = pool
+	}
+
+	namespace := "default"
+	if data, err := os.ReadFile(filepath.Join(serviceAccountDir, "namespace")); err == nil {
+		namespace = strings.TrimSpace(string(data))
+	}
+
+	// Service account tokens are rotated, so read the file on every request
+	tokenFile := filepath.Join(serviceAccountDir, "token")
+	return &Config{
+		Server:    "https://" + strings.Trim(host, "[]") + ":" + port,
+		Namespace: namespace,
+		tlsConfig: tlsConfig,
+		token:     func() (string, error) { return readToken(tokenFile) },
+	}, nil
+}
+
+// authorize adds the configured credentials to a request
+func (c *Config) authorize(req *http.Request) error {
+	if c.token != nil {
+		token, err := c.token()
+		if err != nil {
+			return err
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+			return nil
+		}
+	}
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	return nil
+}
+
+// dataOrFile returns base64 inline data, or the contents of file when no data is given
+func dataOrFile(data, file, dir string) ([]byte, error) {
+	if data != "" {
+		return base64.StdEncoding.DecodeString(data)
+	}
+	if file != "" {
+		return os.ReadFile(resolvePath(file, dir))
+	}
+	return nil, nil
+}
+
+func resolvePath(path, dir string) string {
+	if path == "" || filepath.IsAbs(path) || dir == "" {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+func readToken(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// newExecTokenSource runs a credential plugin and caches the token until it expires
+func newExecTokenSource(cfg *execConfig) func() (string, error) {
+	var (
+		mu      sync.Mutex
+		token   string
+		expires time.Time
+	)
+
+	return func() (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if token != "" && (expires.IsZero() || time.Now().Before(expires.Add(-30*time.Second))) {
+			return token, nil
+		}
+
+		cmd := exec.Command(cfg.Command, cfg.Args...)
+		cmd.Env = os.Environ()
+		for _, env := range cfg.Env {
+			cmd.Env = append(cmd.Env, env.Name+"="+env.Value)
+		}
+		apiVersion := cfg.APIVersion
+		if apiVersion == "" {
+			apiVersion = "client.authentication.k8s.io/v1beta1"
+		}
+		cmd.Env = append(cmd.Env, fmt.Sprintf(`KUBERNETES_EXEC_INFO={"apiVersion":%q,"kind":"ExecCredential","spec":{"interactive":false}}`, apiVersion))
+
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("credential plugin %s failed: %w: %s", cfg.Command, err, strings.TrimSpace(stderr.String()))
+		}
+
+		var credential struct {
+			Status struct {
+				Token               string    `json:"token"`
+				ExpirationTimestamp time.Time `json:"expirationTimestamp"`
+			} `json:"status"`
+		}
+		if err := json.Unmarshal(output, &credential); err != nil {
+			return "", fmt.Errorf("credential plugin %s returned invalid output: %w", cfg.Command, err)
+		}
+
+		token = credential.Status.Token
+		expires = credential.Status.ExpirationTimestamp
+		return token, nil
+	}
+}
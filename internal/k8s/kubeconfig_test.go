@@ -0,0 +1,225 @@
+package k8s
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeKubeconfig writes a kubeconfig file and returns its path
+func writeKubeconfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// newPodListServer creates an API server, started by the caller with TLS, that
+// answers authorized pod lists with an empty list
+func newPodListServer(t *testing.T, check func(r *http.Request) bool) *httptest.Server {
+	t.Helper()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !check(r) {
+			http.Error(w, `{"kind":"Status","code":401}`, http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"metadata":{"resourceVersion":"1"},"items":[]}`))
+	}))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0) // Rejected handshakes are expected
+	return server
+}
+
+// listPods lists pods with a config, returning the error
+func listPods(cfg *Config) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, _, err := NewClient(cfg).ListPods(ctx, cfg.Namespace, "")
+	return err
+}
+
+// newClientCertificate creates a self-signed client certificate and returns it
+// with its PEM encoded certificate and key
+func newClientCertificate(t *testing.T) (*x509.Certificate, []byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "gonzo"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestLoadConfigToken(t *testing.T) {
+	server := newPodListServer(t, func(r *http.Request) bool {
+		return r.Header.Get("Authorization") == "Bearer s3cret" && r.URL.Path == "/api/v1/namespaces/shop/pods"
+	})
+	server.StartTLS()
+	defer server.Close()
+
+	path := writeKubeconfig(t, `apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: dev
+  cluster:
+    server: `+server.URL+`/
+    insecure-skip-tls-verify: true
+- name: prod
+  cluster:
+    server: https://prod.example.com
+users:
+- name: dev-user
+  user:
+    token: s3cret
+contexts:
+- name: prod
+  context:
+    cluster: prod
+    user: dev-user
+- name: dev
+  context:
+    cluster: dev
+    user: dev-user
+    namespace: shop
+`)
+
+	cfg, err := LoadConfig(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server != server.URL || cfg.Namespace != "shop" {
+		t.Errorf("server = %q, namespace = %q, want %q and shop", cfg.Server, cfg.Namespace, server.URL)
+	}
+	if !cfg.tlsConfig.InsecureSkipVerify {
+		t.Error("insecure-skip-tls-verify is not applied")
+	}
+	if err := listPods(cfg); err != nil {
+		t.Errorf("listing pods with the token failed: %v", err)
+	}
+
+	cfg, err = LoadConfig(path, "prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server != "https://prod.example.com" || cfg.tlsConfig.InsecureSkipVerify {
+		t.Errorf("--k8s-context prod gives server %q, insecure %v", cfg.Server, cfg.tlsConfig.InsecureSkipVerify)
+	}
+
+	if _, err := LoadConfig(path, "missing"); err == nil || !strings.Contains(err.Error(), `context "missing" not found`) {
+		t.Errorf("missing context error = %v", err)
+	}
+}
+
+func TestLoadConfigVerifiesServer(t *testing.T) {
+	server := newPodListServer(t, func(*http.Request) bool { return true })
+	server.StartTLS()
+	defer server.Close()
+
+	path := writeKubeconfig(t, `current-context: dev
+clusters:
+- name: dev
+  cluster:
+    server: `+server.URL+`
+users:
+- name: dev
+  user: {}
+contexts:
+- name: dev
+  context: {cluster: dev, user: dev}
+`)
+
+	cfg, err := LoadConfig(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := listPods(cfg); err == nil {
+		t.Error("server certificate was accepted without certificate authority or insecure-skip-tls-verify")
+	}
+}
+
+func TestLoadConfigClientCertificate(t *testing.T) {
+	clientCert, certPEM, keyPEM := newClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+
+	server := newPodListServer(t, func(r *http.Request) bool {
+		return r.TLS != nil && len(r.TLS.PeerCertificates) > 0 && r.TLS.PeerCertificates[0].Subject.CommonName == "gonzo"
+	})
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	// The key is read from a file relative to the kubeconfig, the rest is inline
+	path := writeKubeconfig(t, `current-context: dev
+clusters:
+- name: dev
+  cluster:
+    server: `+server.URL+`
+    certificate-authority-data: `+base64.StdEncoding.EncodeToString(serverCA)+`
+users:
+- name: dev
+  user:
+    client-certificate-data: `+base64.StdEncoding.EncodeToString(certPEM)+`
+    client-key: client.key
+contexts:
+- name: dev
+  context: {cluster: dev, user: dev}
+`)
+	if err := os.WriteFile(filepath.Join(filepath.Dir(path), "client.key"), keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.tlsConfig.InsecureSkipVerify || cfg.tlsConfig.RootCAs == nil || len(cfg.tlsConfig.Certificates) != 1 {
+		t.Fatalf("TLS config is not set up from the kubeconfig: %+v", cfg.tlsConfig)
+	}
+	if err := listPods(cfg); err != nil {
+		t.Errorf("listing pods with the client certificate failed: %v", err)
+	}
+}
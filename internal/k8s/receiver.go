@@ -0,0 +1,351 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/multiline"
+)
+
+// Attribute keys attached to every line (OpenTelemetry semantic conventions)
+const (
+	AttrNamespaceName = "k8s.namespace.name"
+	AttrPodName       = "k8s.pod.name"
+	AttrContainerName = "k8s.container.name"
+	AttrNodeName      = "k8s.node.name"
+)
+
+// Retry delays after a failed watch or log stream
+const (
+	watchRetryDelay  = time.Second
+	streamRetryDelay = 2 * time.Second
+)
+
+// Line is a log line of a container together with the attributes identifying it
+type Line struct {
+	Text       string
+	Attributes map[string]string
+}
+
+// ReceiverOptions selects the pods and containers to follow
+type ReceiverOptions struct {
+	Namespace string           // Namespace to follow (empty = all namespaces)
+	Selector  string           // Label selector, e.g. "app=checkout,tier!=cache"
+	Container string           // Only follow containers with this name (empty = all containers)
+	TailLines int              // Existing lines to show per container on startup (negative = whole log)
+	Multiline multiline.Config // Joins multi-line records, assembled separately for every container
+}
+
+// Receiver follows the logs of all containers of the matching pods, picking up
+// new pods and container restarts as they happen
+type Receiver struct {
+	client     *Client
+	opts       ReceiverOptions
+	lineChan   chan Line
+	ctx        context.Context
+	cancelFunc context.CancelFunc
+	startTime  time.Time
+
+	mu      sync.Mutex
+	streams map[string]*containerStream // Keyed by namespace/pod/container
+	wg      sync.WaitGroup
+}
+
+// containerStream tracks one container of a followed pod
+type containerStream struct {
+	pod           string // namespace/pod
+	running       bool   // Container is running according to the latest pod status
+	active        bool   // A log stream is currently being read
+	cancel        context.CancelFunc
+	lastTimestamp time.Time // Timestamp of the last line, used to resume without duplicates
+}
+
+// NewReceiver creates a new Kubernetes log receiver
+func NewReceiver(client *Client, opts ReceiverOptions) *Receiver {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Receiver{
+		client:     client,
+		opts:       opts,
+		lineChan:   make(chan Line, 100),
+		ctx:        ctx,
+		cancelFunc: cancel,
+		streams:    make(map[string]*containerStream),
+	}
+}
+
+// Start lists the matching pods and begins following their logs. The initial
+// listing is done synchronously so that connection and permission problems are
+// reported to the caller.
+func (r *Receiver) Start() error {
+	if r.opts.Multiline.Enabled() {
+		// Validate the patterns once instead of failing in every container stream
+		if _, err := multiline.NewAssembler(r.opts.Multiline); err != nil {
+			return err
+		}
+	}
+
+	r.startTime = time.Now()
+	pods, resourceVersion, err := r.client.ListPods(r.ctx, r.opts.Namespace, r.opts.Selector)
+	if err != nil {
+		return err
+	}
+
+	go r.run(pods, resourceVersion)
+	return nil
+}
+
+// Stop stops following all pods
+func (r *Receiver) Stop() {
+	if r.cancelFunc != nil {
+		r.cancelFunc()
+	}
+}
+
+// GetLineChan returns the channel for receiving log lines
+func (r *Receiver) GetLineChan() <-chan Line {
+	return r.lineChan
+}
+
+// run keeps the set of followed containers in sync with the pods on the cluster
+func (r *Receiver) run(pods []Pod, resourceVersion string) {
+	defer close(r.lineChan)
+	defer r.wg.Wait()
+
+	r.syncPods(pods)
+
+	for r.ctx.Err() == nil {
+		if resourceVersion == "" {
+			// Start over from a fresh listing (first run after an expired or failed watch)
+			var err error
+			pods, resourceVersion, err = r.client.ListPods(r.ctx, r.opts.Namespace, r.opts.Selector)
+			if err != nil {
+				resourceVersion = ""
+				r.sleep(watchRetryDelay)
+				continue
+			}
+			r.syncPods(pods)
+		}
+
+		err := r.client.WatchPods(r.ctx, r.opts.Namespace, r.opts.Selector, resourceVersion, func(event PodEvent) error {
+			resourceVersion = event.Object.Metadata.ResourceVersion
+			if event.Type == "DELETED" {
+				r.removePod(podKey(event.Object))
+			} else {
+				r.updatePod(event.Object)
+			}
+			return nil
+		})
+		if err != nil {
+			// Silently relist to avoid UI interference; errWatchExpired is the common case
+			if !errors.Is(err, errWatchExpired) && !errors.Is(err, context.Canceled) {
+				r.sleep(watchRetryDelay)
+			}
+			resourceVersion = ""
+		}
+	}
+}
+
+// syncPods updates all listed pods and stops following pods that are gone
+func (r *Receiver) syncPods(pods []Pod) {
+	present := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		present[podKey(pod)] = true
+		r.updatePod(pod)
+	}
+
+	r.mu.Lock()
+	var gone []string
+	for _, stream := range r.streams {
+		if !present[stream.pod] {
+			gone = append(gone, stream.pod)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, pod := range gone {
+		r.removePod(pod)
+	}
+}
+
+// updatePod starts streams for running containers that are not followed yet
+func (r *Receiver) updatePod(pod Pod) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if r.opts.Container != "" && status.Name != r.opts.Container {
+			continue
+		}
+
+		key := podKey(pod) + "/" + status.Name
+		stream, ok := r.streams[key]
+		if !ok {
+			stream = &containerStream{pod: podKey(pod)}
+			r.streams[key] = stream
+		}
+
+		stream.running = status.State.Running != nil
+		if !stream.running || stream.active {
+			continue
+		}
+
+		// Resume after the last line seen, show the whole log of containers started
+		// after gonzo, and only the tail of containers that were already running
+		opts := LogOptions{TailLines: r.opts.TailLines}
+		if !stream.lastTimestamp.IsZero() {
+			opts = LogOptions{SinceTime: stream.lastTimestamp}
+		} else if status.State.Running.StartedAt.After(r.startTime) {
+			opts = LogOptions{TailLines: -1}
+		}
+
+		ctx, cancel := context.WithCancel(r.ctx)
+		stream.active = true
+		stream.cancel = cancel
+
+		attributes := map[string]string{
+			AttrNamespaceName: pod.Metadata.Namespace,
+			AttrPodName:       pod.Metadata.Name,
+			AttrContainerName: status.Name,
+		}
+		if pod.Spec.NodeName != "" {
+			attributes[AttrNodeName] = pod.Spec.NodeName
+		}
+
+		r.wg.Add(1)
+		go r.follow(ctx, key, stream, attributes, opts)
+	}
+}
+
+// removePod stops following all containers of a deleted pod
+func (r *Receiver) removePod(pod string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, stream := range r.streams {
+		if stream.pod == pod {
+			if stream.cancel != nil {
+				stream.cancel()
+			}
+			delete(r.streams, key)
+		}
+	}
+}
+
+// follow streams a container log until the container stops or the pod goes away
+func (r *Receiver) follow(ctx context.Context, key string, stream *containerStream, attributes map[string]string, opts LogOptions) {
+	defer r.wg.Done()
+	defer func() {
+		r.mu.Lock()
+		stream.active = false
+		r.mu.Unlock()
+	}()
+
+	send := func(text string) error {
+		select {
+		case r.lineChan <- Line{Text: text, Attributes: attributes}:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	emit := send
+
+	// Multi-line records must not mix lines of different containers, so every
+	// container gets its own assembler
+	if r.opts.Multiline.Enabled() {
+		if assembler, err := multiline.NewAssembler(r.opts.Multiline); err == nil {
+			lines := make(chan string, 100)
+			records := make(chan string, 100)
+			done := make(chan struct{})
+			go assembler.Run(ctx, lines, records)
+			go func() {
+				defer close(done)
+				for record := range records {
+					send(record)
+				}
+			}()
+			defer func() {
+				close(lines)
+				<-done
+			}()
+
+			emit = func(text string) error {
+				select {
+				case lines <- text:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+	}
+
+	namespace, pod, container := splitStreamKey(key)
+	for {
+		r.mu.Lock()
+		resumeAfter := stream.lastTimestamp
+		r.mu.Unlock()
+		lastTimestamp := resumeAfter
+
+		r.client.StreamLogs(ctx, namespace, pod, container, opts, func(raw string) error {
+			text := raw
+			if i := strings.IndexByte(raw, ' '); i > 0 {
+				if ts, err := time.Parse(time.RFC3339Nano, raw[:i]); err == nil {
+					// sinceTime has second precision, skip the lines replayed up to the
+					// resume point. Later lines may share a timestamp and are all kept.
+					if !resumeAfter.IsZero() {
+						if !ts.After(resumeAfter) {
+							return nil
+						}
+						resumeAfter = time.Time{}
+					}
+					lastTimestamp = ts
+					r.mu.Lock()
+					stream.lastTimestamp = ts
+					r.mu.Unlock()
+					text = raw[i+1:]
+				}
+			}
+			return emit(text)
+		})
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		// The log ends when the container stops; a restart is picked up by the pod
+		// watch. If the container is still running the connection dropped, so resume.
+		r.sleep(streamRetryDelay)
+		r.mu.Lock()
+		running := stream.running
+		r.mu.Unlock()
+		if !running || ctx.Err() != nil {
+			return
+		}
+		opts = LogOptions{SinceTime: lastTimestamp, TailLines: -1}
+	}
+}
+
+// sleep waits for d or until the receiver stops
+func (r *Receiver) sleep(d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-r.ctx.Done():
+	}
+}
+
+func podKey(pod Pod) string {
+	return pod.Metadata.Namespace + "/" + pod.Metadata.Name
+}
+
+func splitStreamKey(key string) (namespace, pod, container string) {
+	parts := strings.SplitN(key, "/", 3)
+	return parts[0], parts[1], parts[2]
+}
@@ -0,0 +1,275 @@
+package k8s
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAPIServer is a Kubernetes API server stub serving pod lists, watches and logs
+type fakeAPIServer struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	calls []string // "list", "watch rv=..." and "log pod query" in order
+
+	list  func(n int) (pods []map[string]any, resourceVersion string)
+	watch func(n int, w *fakeWatch)
+	logs  func(pod string, n int, w *fakeStream)
+}
+
+// fakeWatch writes watch events
+type fakeWatch struct {
+	w http.ResponseWriter
+	r *http.Request
+}
+
+// fakeStream writes log lines
+type fakeStream = fakeWatch
+
+func newFakeAPIServer(t *testing.T) *fakeAPIServer {
+	t.Helper()
+	f := &fakeAPIServer{}
+	lists, watches, logs := 0, 0, map[string]int{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		stream := &fakeWatch{w: w, r: r}
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/log"):
+			parts := strings.Split(r.URL.Path, "/")
+			pod := parts[len(parts)-2]
+			f.record(fmt.Sprintf("log %s sinceTime=%s tailLines=%s", pod, query.Get("sinceTime"), query.Get("tailLines")))
+			f.mu.Lock()
+			n := logs[pod]
+			logs[pod]++
+			f.mu.Unlock()
+			f.logs(pod, n, stream)
+
+		case query.Get("watch") == "true":
+			f.record("watch rv=" + query.Get("resourceVersion"))
+			f.mu.Lock()
+			n := watches
+			watches++
+			f.mu.Unlock()
+			f.watch(n, stream)
+
+		default:
+			f.record("list")
+			f.mu.Lock()
+			n := lists
+			lists++
+			f.mu.Unlock()
+			pods, resourceVersion := f.list(n)
+			json.NewEncoder(w).Encode(map[string]any{
+				"metadata": map[string]any{"resourceVersion": resourceVersion},
+				"items":    pods,
+			})
+		}
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeAPIServer) record(call string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, call)
+}
+
+func (f *fakeAPIServer) recorded() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+// client returns a client for the fake server
+func (f *fakeAPIServer) client() *Client {
+	return NewClient(&Config{Server: f.URL, tlsConfig: &tls.Config{}})
+}
+
+// send writes a watch event or log line and flushes it to the client
+func (s *fakeWatch) send(line string) {
+	fmt.Fprintln(s.w, line)
+	s.w.(http.Flusher).Flush()
+}
+
+// event writes a watch event for a pod
+func (s *fakeWatch) event(eventType string, pod map[string]any) {
+	data, _ := json.Marshal(map[string]any{"type": eventType, "object": pod})
+	s.send(string(data))
+}
+
+// hold keeps the response open until the client goes away
+func (s *fakeWatch) hold() {
+	<-s.r.Context().Done()
+}
+
+// fakePod returns a pod object with one running container named app
+func fakePod(name, resourceVersion string) map[string]any {
+	return map[string]any{
+		"metadata": map[string]any{"name": name, "namespace": "shop", "resourceVersion": resourceVersion},
+		"spec":     map[string]any{"nodeName": "node-1"},
+		"status": map[string]any{
+			"phase": "Running",
+			"containerStatuses": []map[string]any{{
+				"name":  "app",
+				"state": map[string]any{"running": map[string]any{"startedAt": "2024-05-01T13:00:00Z"}},
+			}},
+		},
+	}
+}
+
+// receiveLines reads n lines from the receiver
+func receiveLines(t *testing.T, r *Receiver, n int) []Line {
+	t.Helper()
+	var lines []Line
+	timeout := time.After(10 * time.Second)
+	for len(lines) < n {
+		select {
+		case line, ok := <-r.GetLineChan():
+			if !ok {
+				t.Fatalf("receiver stopped after %d lines, want %d", len(lines), n)
+			}
+			lines = append(lines, line)
+		case <-timeout:
+			t.Fatalf("received %d lines, want %d", len(lines), n)
+		}
+	}
+	return lines
+}
+
+func TestReceiverFollowsPodChanges(t *testing.T) {
+	api := newFakeAPIServer(t)
+	aReceived := make(chan struct{})
+	aCancelled := make(chan struct{})
+
+	api.list = func(n int) ([]map[string]any, string) {
+		if n == 0 {
+			return []map[string]any{fakePod("a", "1")}, "1"
+		}
+		// Relisted after the watch expired: a was deleted meanwhile, c was added
+		return []map[string]any{fakePod("b", "2"), fakePod("c", "7")}, "7"
+	}
+	api.watch = func(n int, w *fakeWatch) {
+		if n > 0 {
+			w.hold()
+			return
+		}
+		w.event("ADDED", fakePod("b", "2"))
+		<-aReceived // Deleting the pod drops the lines not read yet
+		w.event("DELETED", fakePod("a", "3"))
+		w.send(`{"type":"ERROR","object":{"kind":"Status","code":410,"message":"too old resource version"}}`)
+	}
+	api.logs = func(pod string, n int, w *fakeStream) {
+		w.send("2024-05-01T14:00:00Z hello from " + pod)
+		if pod == "a" {
+			w.hold()
+			close(aCancelled)
+			return
+		}
+		w.hold()
+	}
+
+	r := NewReceiver(api.client(), ReceiverOptions{Namespace: "shop", TailLines: 10})
+	if err := r.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(r.Stop)
+
+	var texts []string
+	for len(texts) < 3 {
+		line := receiveLines(t, r, 1)[0]
+		if line.Text == "hello from a" {
+			close(aReceived)
+		}
+		texts = append(texts, line.Text)
+		if line.Attributes[AttrPodName] != strings.TrimPrefix(line.Text, "hello from ") {
+			t.Errorf("line %q has pod attribute %q", line.Text, line.Attributes[AttrPodName])
+		}
+		if line.Attributes[AttrNamespaceName] != "shop" || line.Attributes[AttrContainerName] != "app" || line.Attributes[AttrNodeName] != "node-1" {
+			t.Errorf("line %q has attributes %v", line.Text, line.Attributes)
+		}
+	}
+	sort.Strings(texts)
+	if want := "hello from a,hello from b,hello from c"; strings.Join(texts, ",") != want {
+		t.Errorf("lines = %v, want %s", texts, want)
+	}
+
+	select {
+	case <-aCancelled:
+	case <-time.After(5 * time.Second):
+		t.Error("log stream of the deleted pod was not stopped")
+	}
+
+	var watchesAndLists []string
+	for _, call := range api.recorded() {
+		if !strings.HasPrefix(call, "log ") {
+			watchesAndLists = append(watchesAndLists, call)
+		}
+	}
+	if want := "list,watch rv=1,list,watch rv=7"; strings.Join(watchesAndLists, ",") != want {
+		t.Errorf("calls = %v, want %s", watchesAndLists, want)
+	}
+}
+
+func TestReceiverResumesLogStream(t *testing.T) {
+	api := newFakeAPIServer(t)
+	api.list = func(int) ([]map[string]any, string) {
+		return []map[string]any{fakePod("r", "1")}, "1"
+	}
+	api.watch = func(_ int, w *fakeWatch) { w.hold() }
+	api.logs = func(_ string, n int, w *fakeStream) {
+		w.send("2024-05-01T14:00:00.100000000Z one")
+		w.send("2024-05-01T14:00:00.500000000Z two")
+		w.send("2024-05-01T14:00:00.500000000Z two again") // Written in the same burst
+		if n == 0 {
+			return // Connection dropped while the container keeps running
+		}
+		// sinceTime has second precision, so the resumed stream repeats the lines above
+		w.send("2024-05-01T14:00:01.000000000Z three")
+		w.send("2024-05-01T14:00:01.000000000Z three again")
+		w.hold()
+	}
+
+	r := NewReceiver(api.client(), ReceiverOptions{Namespace: "shop", TailLines: 10})
+	if err := r.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(r.Stop)
+
+	var texts []string
+	for _, line := range receiveLines(t, r, 5) {
+		texts = append(texts, line.Text)
+	}
+	if want := "one,two,two again,three,three again"; strings.Join(texts, ",") != want {
+		t.Errorf("lines = %v, want %s", texts, want)
+	}
+
+	select {
+	case line := <-r.GetLineChan():
+		t.Errorf("unexpected line %q after resume", line.Text)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	var logCalls []string
+	for _, call := range api.recorded() {
+		if strings.HasPrefix(call, "log ") {
+			logCalls = append(logCalls, call)
+		}
+	}
+	want := []string{
+		"log r sinceTime= tailLines=10",
+		"log r sinceTime=2024-05-01T14:00:00Z tailLines=",
+	}
+	if strings.Join(logCalls, ",") != strings.Join(want, ",") {
+		t.Errorf("log requests = %v, want %v", logCalls, want)
+	}
+}
@@ -19,6 +19,7 @@ type AttributeStats struct {
 	TotalCount   int64
 	LastSeen     time.Time
 	FirstSeen    time.Time
+	Numeric      *Sketch // Distribution of the values that are numbers
 }
 
 type AttributeStatsEntry struct {
@@ -28,6 +29,12 @@ type AttributeStatsEntry struct {
 	LastSeen          time.Time
 	FirstSeen         time.Time
 	Values           map[string]int64 // Individual values and their counts
+	Numeric          *Sketch          // Distribution of the numeric values
+}
+
+// IsNumeric reports whether the attribute holds numbers, like durations or status codes
+func (e *AttributeStatsEntry) IsNumeric() bool {
+	return e.Numeric != nil && IsNumeric(e.Numeric.Count, e.TotalCount)
 }
 
 type FrequencyMemory struct {
@@ -124,6 +131,15 @@ func (fm *FrequencyMemory) AddAttributes(attributes map[string]string) {
 				LastSeen:     now,
 			}
 		}
+
+		// Numbers also go into a sketch, so percentiles are available
+		if number, ok := ParseNumeric(value); ok {
+			stats := fm.attributes[key]
+			if stats.Numeric == nil {
+				stats.Numeric = NewSketch()
+			}
+			stats.Numeric.Add(number)
+		}
 	}
 	
 	if len(fm.attributes) > fm.maxSize {
@@ -177,6 +193,11 @@ func (fm *FrequencyMemory) GetSnapshot() *FrequencySnapshot {
 			valuesCopy[key] = count
 		}
 		
+		var numeric *Sketch
+		if stats.Numeric != nil {
+			numeric = stats.Numeric.Clone()
+		}
+
 		attributes = append(attributes, &AttributeStatsEntry{
 			Key:               stats.Key,
 			UniqueValueCount:  len(stats.UniqueValues),
@@ -184,6 +205,7 @@ func (fm *FrequencyMemory) GetSnapshot() *FrequencySnapshot {
 			FirstSeen:         stats.FirstSeen,
 			LastSeen:          stats.LastSeen,
 			Values:           valuesCopy,
+			Numeric:          numeric,
 		})
 	}
 	
@@ -257,6 +279,7 @@ func (fm *FrequencyMemory) pruneAttributes() {
 			FirstSeen:         stats.FirstSeen,
 			LastSeen:          stats.LastSeen,
 			Values:           valuesCopy,
+			Numeric:          stats.Numeric,
 		})
 	}
 	
@@ -278,6 +301,7 @@ func (fm *FrequencyMemory) pruneAttributes() {
 			TotalCount:   entry.TotalCount,
 			FirstSeen:    entry.FirstSeen,
 			LastSeen:     entry.LastSeen,
+			Numeric:      entry.Numeric,
 		}
 	}
 }
@@ -289,4 +313,45 @@ func (fm *FrequencyMemory) Reset() {
 	fm.words = make(map[string]*FrequencyEntry)
 	fm.phrases = make(map[string]*FrequencyEntry)
 	fm.attributes = make(map[string]*AttributeStats)
-}
\ No newline at end of file
+}
+// Restore replaces the current contents with the data from a snapshot
+func (fm *FrequencyMemory) Restore(snapshot *FrequencySnapshot) {
+	if snapshot == nil {
+		return
+	}
+
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+
+	fm.words = make(map[string]*FrequencyEntry, len(snapshot.Words))
+	for _, entry := range snapshot.Words {
+		copied := *entry
+		fm.words[entry.Term] = &copied
+	}
+
+	fm.phrases = make(map[string]*FrequencyEntry, len(snapshot.Phrases))
+	for _, entry := range snapshot.Phrases {
+		copied := *entry
+		fm.phrases[entry.Term] = &copied
+	}
+
+	fm.attributes = make(map[string]*AttributeStats, len(snapshot.Attributes))
+	for _, entry := range snapshot.Attributes {
+		values := make(map[string]int64, len(entry.Values))
+		for value, count := range entry.Values {
+			values[value] = count
+		}
+		var numeric *Sketch
+		if entry.Numeric != nil {
+			numeric = entry.Numeric.Clone()
+		}
+		fm.attributes[entry.Key] = &AttributeStats{
+			Key:          entry.Key,
+			UniqueValues: values,
+			TotalCount:   entry.TotalCount,
+			FirstSeen:    entry.FirstSeen,
+			LastSeen:     entry.LastSeen,
+			Numeric:      numeric,
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package memory
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sketchAccuracy is the relative error of the quantiles a Sketch returns
+const sketchAccuracy = 0.02
+
+// sketchMaxBuckets bounds the memory of a sketch; beyond it the buckets of the
+// smallest values are folded together, losing accuracy only at the low end
+const sketchMaxBuckets = 2048
+
+// sketchMinValue is the smallest magnitude kept apart from zero
+const sketchMinValue = 1e-9
+
+// numericThreshold is the share of values that has to be numeric for an
+// attribute to be treated as numeric
+const numericThreshold = 0.9
+
+// sketchLogGamma is the logarithm of the ratio between the bounds of a bucket
+var sketchLogGamma = math.Log((1 + sketchAccuracy) / (1 - sketchAccuracy))
+
+// Sketch is a streaming quantile sketch. Values are counted in logarithmic
+// buckets, so quantiles are within sketchAccuracy of the exact value while
+// the memory used depends on the range of the values, not on their number.
+// Sketches of the same kind of value can be merged.
+type Sketch struct {
+	Count    int64                `json:"count"`
+	Sum      float64              `json:"sum"`
+	Min      float64              `json:"min"`
+	Max      float64              `json:"max"`
+	Zero     int64                `json:"zero,omitempty"`
+	Positive map[int]SketchBucket `json:"positive,omitempty"`
+	Negative map[int]SketchBucket `json:"negative,omitempty"` // By magnitude
+}
+
+// SketchBucket counts the values of one bucket. Their sum gives the bucket's
+// mean, which is exact for buckets holding a single value, like status codes.
+type SketchBucket struct {
+	Count int64   `json:"n"`
+	Sum   float64 `json:"s"`
+}
+
+// mean returns the average value of the bucket
+func (b SketchBucket) mean() float64 {
+	return b.Sum / float64(b.Count)
+}
+
+// NewSketch creates an empty sketch
+func NewSketch() *Sketch {
+	return &Sketch{
+		Positive: make(map[int]SketchBucket),
+		Negative: make(map[int]SketchBucket),
+	}
+}
+
+// Add adds a value to the sketch
+func (s *Sketch) Add(value float64) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return
+	}
+
+	if s.Count == 0 || value < s.Min {
+		s.Min = value
+	}
+	if s.Count == 0 || value > s.Max {
+		s.Max = value
+	}
+	s.Count++
+	s.Sum += value
+
+	switch {
+	case value >= sketchMinValue:
+		addToBucket(s.buckets(&s.Positive), sketchIndex(value), SketchBucket{Count: 1, Sum: value})
+	case value <= -sketchMinValue:
+		addToBucket(s.buckets(&s.Negative), sketchIndex(-value), SketchBucket{Count: 1, Sum: -value})
+	default:
+		s.Zero++
+	}
+
+	if len(s.Positive)+len(s.Negative) > sketchMaxBuckets {
+		s.collapse()
+	}
+}
+
+// Merge adds the values of another sketch
+func (s *Sketch) Merge(other *Sketch) {
+	if other == nil || other.Count == 0 {
+		return
+	}
+
+	if s.Count == 0 || other.Min < s.Min {
+		s.Min = other.Min
+	}
+	if s.Count == 0 || other.Max > s.Max {
+		s.Max = other.Max
+	}
+	s.Count += other.Count
+	s.Sum += other.Sum
+	s.Zero += other.Zero
+
+	positive := s.buckets(&s.Positive)
+	for index, bucket := range other.Positive {
+		addToBucket(positive, index, bucket)
+	}
+	negative := s.buckets(&s.Negative)
+	for index, bucket := range other.Negative {
+		addToBucket(negative, index, bucket)
+	}
+
+	for len(s.Positive)+len(s.Negative) > sketchMaxBuckets {
+		s.collapse()
+	}
+}
+
+// Clone returns a copy of the sketch
+func (s *Sketch) Clone() *Sketch {
+	clone := NewSketch()
+	clone.Merge(s)
+	return clone
+}
+
+// Mean returns the average of the values, 0 for an empty sketch
+func (s *Sketch) Mean() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Sum / float64(s.Count)
+}
+
+// Quantile returns the value below which the fraction q of the values lie,
+// e.g. 0.99 for the 99th percentile. It returns 0 for an empty sketch.
+func (s *Sketch) Quantile(q float64) float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	if q <= 0 {
+		return s.Min
+	}
+	if q >= 1 {
+		return s.Max
+	}
+
+	rank := int64(q * float64(s.Count-1))
+	var seen int64
+
+	// Negative values first, the largest magnitudes are the smallest values
+	for _, index := range sortedIndexes(s.Negative, true) {
+		seen += s.Negative[index].Count
+		if seen > rank {
+			return s.clamp(-s.Negative[index].mean())
+		}
+	}
+	seen += s.Zero
+	if seen > rank {
+		return s.clamp(0)
+	}
+	for _, index := range sortedIndexes(s.Positive, false) {
+		seen += s.Positive[index].Count
+		if seen > rank {
+			return s.clamp(s.Positive[index].mean())
+		}
+	}
+	return s.Max
+}
+
+// Histogram counts the values in each of the ranges ending at the given upper
+// bounds, which have to be ascending. Values above the last bound count in the
+// last range.
+func (s *Sketch) Histogram(bounds []float64) []int64 {
+	counts := make([]int64, len(bounds))
+	if len(bounds) == 0 {
+		return counts
+	}
+
+	add := func(value float64, count int64) {
+		bin := sort.SearchFloat64s(bounds, s.clamp(value))
+		counts[min(bin, len(bounds)-1)] += count
+	}
+	for _, bucket := range s.Negative {
+		add(-bucket.mean(), bucket.Count)
+	}
+	if s.Zero > 0 {
+		add(0, s.Zero)
+	}
+	for _, bucket := range s.Positive {
+		add(bucket.mean(), bucket.Count)
+	}
+	return counts
+}
+
+// clamp keeps a bucket estimate within the values actually seen
+func (s *Sketch) clamp(value float64) float64 {
+	return math.Max(s.Min, math.Min(s.Max, value))
+}
+
+// buckets returns a bucket map, creating it for sketches restored from JSON
+func (s *Sketch) buckets(m *map[int]SketchBucket) map[int]SketchBucket {
+	if *m == nil {
+		*m = make(map[int]SketchBucket)
+	}
+	return *m
+}
+
+// collapse folds the bucket of the smallest magnitude into its neighbour
+func (s *Sketch) collapse() {
+	buckets := s.Positive
+	if len(s.Negative) > len(s.Positive) {
+		buckets = s.Negative
+	}
+	indexes := sortedIndexes(buckets, false)
+	if len(indexes) < 2 {
+		return
+	}
+	addToBucket(buckets, indexes[1], buckets[indexes[0]])
+	delete(buckets, indexes[0])
+}
+
+// addToBucket adds counted values to a bucket
+func addToBucket(buckets map[int]SketchBucket, index int, values SketchBucket) {
+	bucket := buckets[index]
+	bucket.Count += values.Count
+	bucket.Sum += values.Sum
+	buckets[index] = bucket
+}
+
+// sketchIndex returns the bucket of a positive value
+func sketchIndex(value float64) int {
+	return int(math.Ceil(math.Log(value) / sketchLogGamma))
+}
+
+// sortedIndexes returns the bucket indexes of a bucket map in order
+func sortedIndexes(buckets map[int]SketchBucket, descending bool) []int {
+	indexes := make([]int, 0, len(buckets))
+	for index := range buckets {
+		indexes = append(indexes, index)
+	}
+	if descending {
+		sort.Sort(sort.Reverse(sort.IntSlice(indexes)))
+	} else {
+		sort.Ints(indexes)
+	}
+	return indexes
+}
+
+// ParseNumeric parses an attribute value as a number. Only plain decimal
+// numbers are accepted, so that IDs, versions and hex values stay strings.
+func ParseNumeric(value string) (float64, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" || len(value) > 32 {
+		return 0, false
+	}
+	for _, r := range value {
+		if (r < '0' || r > '9') && r != '.' && r != '-' && r != '+' && r != 'e' && r != 'E' {
+			return 0, false
+		}
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, false
+	}
+	return number, true
+}
+
+// IsNumeric reports whether enough of the values counted in total were numbers
+// (numeric of them) for the attribute to be treated as numeric
+func IsNumeric(numeric, total int64) bool {
+	return numeric > 0 && float64(numeric) >= numericThreshold*float64(total)
+}
+
+// FormatNumber formats a number compactly for charts and reports, e.g. 404,
+// 12.35 or 48.2k
+func FormatNumber(value float64) string {
+	abs := math.Abs(value)
+	switch {
+	case abs >= 999.95e6:
+		return strconv.FormatFloat(value/1e9, 'f', 1, 64) + "G"
+	case abs >= 999.95e3:
+		return strconv.FormatFloat(value/1e6, 'f', 1, 64) + "M"
+	case abs >= 9999.5:
+		return strconv.FormatFloat(value/1e3, 'f', 1, 64) + "k"
+	}
+	return strconv.FormatFloat(value, 'g', 4, 64)
+}
@@ -0,0 +1,230 @@
+package multiline
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Default assembler limits
+const (
+	DefaultTimeout  = 500 * time.Millisecond
+	DefaultMaxLines = 500
+)
+
+// Config describes how consecutive lines are grouped into a single record.
+//
+// A line is appended to the record being assembled when it matches
+// ContinuationPattern, or when StartPattern is set and the line does not match
+// it. Any other line starts a new record.
+type Config struct {
+	// Preset names one or more built-in rule sets, comma separated (java, python, go, node)
+	Preset string `yaml:"preset,omitempty"`
+
+	// StartPattern is a regex matching the first line of a record
+	// Example: "^\\d{4}-\\d{2}-\\d{2}" for lines beginning with a date
+	StartPattern string `yaml:"start_pattern,omitempty"`
+
+	// ContinuationPattern is a regex matching lines that belong to the previous record
+	// Example: "^\\s+at " for Java stack frames
+	ContinuationPattern string `yaml:"continuation_pattern,omitempty"`
+
+	// Timeout flushes a pending record when no further line arrives in time (default 500ms)
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+
+	// MaxLines flushes a record once it reaches this many lines (default 500)
+	MaxLines int `yaml:"max_lines,omitempty"`
+}
+
+// Enabled reports whether the config asks for any multiline assembly
+func (c Config) Enabled() bool {
+	return c.Preset != "" || c.StartPattern != "" || c.ContinuationPattern != ""
+}
+
+// presets holds the continuation patterns for common runtime stack traces
+var presets = map[string]string{
+	// Stack frames, "... N more", chained causes and a bare exception class line
+	"java": `^\s+at\s|^\s+\.\.\.\s+\d+\s+(more|common frames omitted)|^\s*Caused by:|^\s*Suppressed:|^[\w$.]+(Exception|Error|Throwable)(:\s.*)?$`,
+	// Indented frame and source lines, the final exception line and chained exception notes
+	"python": `^\s+|^[\w.]+(Error|Exception|Warning|Exit|Interrupt)(:\s.*)?$|^During handling of the above exception|^The above exception was the direct cause`,
+	// Goroutine headers, function lines, indented file lines and the exit status after a panic
+	"go": `^\s+|^goroutine \d+ \[|^[\w./*()\-]+\(.*\)$|^created by |^\[signal |^exit status \d+`,
+	// Stack frames and the source excerpt with its caret marker
+	"node": `^\s+at\s|^\s+\.\.\.|^\s*\^+\s*$`,
+}
+
+// Presets returns the names of the built-in presets
+func Presets() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Merge returns c with empty fields filled in from other
+func (c Config) Merge(other Config) Config {
+	if c.Preset == "" {
+		c.Preset = other.Preset
+	}
+	if c.StartPattern == "" {
+		c.StartPattern = other.StartPattern
+	}
+	if c.ContinuationPattern == "" {
+		c.ContinuationPattern = other.ContinuationPattern
+	}
+	if c.Timeout == 0 {
+		c.Timeout = other.Timeout
+	}
+	if c.MaxLines == 0 {
+		c.MaxLines = other.MaxLines
+	}
+	return c
+}
+
+// Assembler joins continuation lines onto the record they belong to
+type Assembler struct {
+	start        *regexp.Regexp
+	continuation *regexp.Regexp
+	timeout      time.Duration
+	maxLines     int
+
+	pending []string
+}
+
+// NewAssembler creates an assembler for the given config
+func NewAssembler(cfg Config) (*Assembler, error) {
+	a := &Assembler{
+		timeout:  cfg.Timeout,
+		maxLines: cfg.MaxLines,
+	}
+	if a.timeout <= 0 {
+		a.timeout = DefaultTimeout
+	}
+	if a.maxLines <= 0 {
+		a.maxLines = DefaultMaxLines
+	}
+
+	var continuations []string
+	if cfg.ContinuationPattern != "" {
+		continuations = append(continuations, cfg.ContinuationPattern)
+	}
+	for _, name := range strings.Split(cfg.Preset, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		pattern, ok := presets[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown multiline preset %q (available: %s)", name, strings.Join(Presets(), ", "))
+		}
+		continuations = append(continuations, pattern)
+	}
+
+	if len(continuations) > 0 {
+		regex, err := regexp.Compile("(?:" + strings.Join(continuations, ")|(?:") + ")")
+		if err != nil {
+			return nil, fmt.Errorf("invalid multiline continuation pattern: %w", err)
+		}
+		a.continuation = regex
+	}
+	if cfg.StartPattern != "" {
+		regex, err := regexp.Compile(cfg.StartPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid multiline start pattern: %w", err)
+		}
+		a.start = regex
+	}
+	if a.start == nil && a.continuation == nil {
+		return nil, fmt.Errorf("multiline assembly needs a preset, start pattern or continuation pattern")
+	}
+
+	return a, nil
+}
+
+// isContinuation reports whether line belongs to the record being assembled
+func (a *Assembler) isContinuation(line string) bool {
+	if a.continuation != nil && a.continuation.MatchString(line) {
+		return true
+	}
+	return a.start != nil && !a.start.MatchString(line)
+}
+
+// Add feeds a line to the assembler and returns any records it completed
+func (a *Assembler) Add(line string) []string {
+	var completed []string
+
+	if len(a.pending) > 0 && !a.isContinuation(line) {
+		completed = append(completed, a.take())
+	}
+	a.pending = append(a.pending, line)
+	if len(a.pending) >= a.maxLines {
+		completed = append(completed, a.take())
+	}
+
+	return completed
+}
+
+// Flush returns the record being assembled, if any
+func (a *Assembler) Flush() (string, bool) {
+	if len(a.pending) == 0 {
+		return "", false
+	}
+	return a.take(), true
+}
+
+func (a *Assembler) take() string {
+	record := strings.Join(a.pending, "\n")
+	a.pending = a.pending[:0]
+	return record
+}
+
+// Run assembles lines from in and writes complete records to out, flushing a
+// pending record after the configured timeout. out is closed once in is closed
+// and the final record has been written.
+func (a *Assembler) Run(ctx context.Context, in <-chan string, out chan<- string) {
+	defer close(out)
+
+	send := func(record string) bool {
+		select {
+		case out <- record:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	timer := time.NewTimer(a.timeout)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case line, ok := <-in:
+			if !ok {
+				if record, ok := a.Flush(); ok {
+					send(record)
+				}
+				return
+			}
+			for _, record := range a.Add(line) {
+				if !send(record) {
+					return
+				}
+			}
+			timer.Stop()
+			if len(a.pending) > 0 {
+				timer.Reset(a.timeout)
+			}
+		case <-timer.C:
+			if record, ok := a.Flush(); ok && !send(record) {
+				return
+			}
+		}
+	}
+}
@@ -58,6 +58,8 @@ func (lc *LogConverter) ConvertToOTLP(line string, format LogFormat) (*logspb.Lo
 		return lc.convertJSONToOTLP(line)
 	case FormatText:
 		return lc.convertTextToOTLP(line)
+	case FormatLogfmt:
+		return lc.convertLogfmtToOTLP(line)
 	case FormatCustom:
 		return lc.convertCustomToOTLP(line)
 	default:
@@ -17,6 +17,7 @@ const (
 	FormatJSON
 	FormatText
 	FormatCustom // Custom user-defined format
+	FormatLogfmt // key=value pairs (logfmt)
 )
 
 // FormatDetector detects the format of incoming log lines
@@ -73,6 +74,8 @@ func (fd *FormatDetector) DetectFormat(line string) LogFormat {
 			return FormatJSON
 		case "text":
 			return FormatText
+		case "logfmt":
+			return FormatLogfmt
 		default:
 			// It's a custom format
 			return FormatCustom
@@ -90,7 +93,12 @@ func (fd *FormatDetector) DetectFormat(line string) LogFormat {
 		return FormatJSON
 	}
 
-	// If not JSON, assume it's plain text
+	// key=value pairs are logfmt
+	if isLogfmt(line) {
+		return FormatLogfmt
+	}
+
+	// Otherwise, assume it's plain text
 	return FormatText
 }
 
@@ -0,0 +1,206 @@
+package otlplog
+
+import (
+	"strconv"
+	"strings"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+)
+
+// Well-known logfmt keys mapped onto the OTLP record instead of attributes
+var (
+	logfmtTimestampKeys = []string{"ts", "time", "timestamp"}
+	logfmtLevelKeys     = []string{"level", "lvl", "severity"}
+	logfmtMessageKeys   = []string{"msg", "message"}
+)
+
+// logfmtLevelAliases maps the four-letter levels of log15/go-ethereum style loggers
+var logfmtLevelAliases = map[string]string{
+	"DBUG": "DEBUG",
+	"EROR": "ERROR",
+}
+
+// logfmtField is a key/value pair of a logfmt line
+type logfmtField struct {
+	Key   string
+	Value string
+}
+
+// parseLogfmt splits a logfmt line (key=value key2="quoted value") into its
+// fields, in order. Bare keys get an empty value. complete reports whether
+// every token was a well-formed key=value pair.
+func parseLogfmt(line string) (fields []logfmtField, complete bool) {
+	complete = true
+	n := len(line)
+	i := 0
+
+	for i < n {
+		// Skip whitespace between pairs
+		for i < n && line[i] <= ' ' {
+			i++
+		}
+		if i >= n {
+			break
+		}
+
+		start := i
+		for i < n && line[i] > ' ' && line[i] != '=' && line[i] != '"' {
+			i++
+		}
+		key := line[start:i]
+
+		if key == "" || i >= n || line[i] != '=' {
+			complete = false
+			if key == "" || (i < n && line[i] == '"') {
+				// Not a key at all (e.g. "=value" or a quoted word), skip the token
+				i = skipLogfmtToken(line, i)
+				continue
+			}
+			fields = append(fields, logfmtField{Key: key})
+			continue
+		}
+		i++ // Skip '='
+
+		if i < n && line[i] == '"' {
+			end, escaped := i+1, false
+			for end < n && line[end] != '"' {
+				if line[end] == '\\' {
+					escaped = true
+					end++
+				}
+				end++
+			}
+			if end >= n {
+				// Unterminated quote, keep the rest of the line as the value
+				fields = append(fields, logfmtField{Key: key, Value: line[i+1:]})
+				return fields, false
+			}
+
+			fields = append(fields, logfmtField{Key: key, Value: unquoteLogfmt(line[i:end+1], escaped)})
+			i = end + 1
+			if i < n && line[i] > ' ' {
+				complete = false // Garbage right after the closing quote
+			}
+			continue
+		}
+
+		start = i
+		for i < n && line[i] > ' ' {
+			if line[i] == '"' {
+				complete = false
+			}
+			i++
+		}
+		fields = append(fields, logfmtField{Key: key, Value: line[start:i]})
+	}
+
+	return fields, complete
+}
+
+// skipLogfmtToken skips to the next whitespace outside of quotes
+func skipLogfmtToken(line string, i int) int {
+	quoted := false
+	for ; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\\' && quoted:
+			i++
+		case c == '"':
+			quoted = !quoted
+		case c <= ' ' && !quoted:
+			return i
+		}
+	}
+	return i
+}
+
+// unquoteLogfmt strips the quotes of a value and resolves Go-style escapes (\" \\ \n \t \uXXXX)
+func unquoteLogfmt(quoted string, escaped bool) string {
+	if escaped {
+		if value, err := strconv.Unquote(quoted); err == nil {
+			return value
+		}
+	}
+	return quoted[1 : len(quoted)-1]
+}
+
+// isLogfmt reports whether a line looks like logfmt: at least two key=value
+// pairs and nothing else
+func isLogfmt(line string) bool {
+	if !strings.Contains(line, "=") {
+		return false
+	}
+	fields, complete := parseLogfmt(line)
+	return complete && len(fields) >= 2
+}
+
+// convertLogfmtToOTLP converts a logfmt line to OTLP format. level, msg and ts/time
+// become the severity, body and timestamp; all other keys become attributes.
+func (lc *LogConverter) convertLogfmtToOTLP(line string) (*logspb.LogRecord, error) {
+	fields, _ := parseLogfmt(strings.TrimSpace(line))
+
+	values := make(map[string]string, len(fields))
+	for _, field := range fields {
+		if _, exists := values[field.Key]; !exists {
+			values[field.Key] = field.Value
+		}
+	}
+
+	record := &logspb.LogRecord{
+		Attributes: []*commonpb.KeyValue{},
+	}
+	used := make(map[string]bool)
+
+	for _, key := range logfmtTimestampKeys {
+		if value, exists := values[key]; exists {
+			if nanos, ok := lc.timestampParser.ParseTimestampToNano(value); ok {
+				record.TimeUnixNano = nanos
+				used[key] = true
+				break
+			}
+		}
+	}
+	if record.TimeUnixNano == 0 {
+		// No usable timestamp key, fall back to a timestamp anywhere in the line
+		record.TimeUnixNano = lc.extractTimestampFromText(line)
+	}
+
+	for _, key := range logfmtLevelKeys {
+		if value, exists := values[key]; exists && value != "" {
+			level := strings.ToUpper(value)
+			if alias, ok := logfmtLevelAliases[level]; ok {
+				level = alias
+			}
+			record.SeverityText = level
+			record.SeverityNumber = lc.severityToNumber(level)
+			used[key] = true
+			break
+		}
+	}
+
+	body := line
+	for _, key := range logfmtMessageKeys {
+		if value, exists := values[key]; exists {
+			body = value
+			used[key] = true
+			break
+		}
+	}
+	record.Body = &commonpb.AnyValue{
+		Value: &commonpb.AnyValue_StringValue{StringValue: body},
+	}
+
+	for _, field := range fields {
+		if used[field.Key] {
+			continue
+		}
+		record.Attributes = append(record.Attributes, &commonpb.KeyValue{
+			Key: field.Key,
+			Value: &commonpb.AnyValue{
+				Value: &commonpb.AnyValue_StringValue{StringValue: field.Value},
+			},
+		})
+	}
+
+	return record, nil
+}
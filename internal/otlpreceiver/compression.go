@@ -0,0 +1,134 @@
+package otlpreceiver
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+
+	// Registers the gzip compressor, the default of OpenTelemetry gRPC exporters
+	_ "google.golang.org/grpc/encoding/gzip"
+)
+
+func init() {
+	encoding.RegisterCompressor(zstdCompressor{})
+}
+
+// errPayloadTooLarge is returned when a request exceeds the maximum payload size
+var errPayloadTooLarge = errors.New("payload too large")
+
+// zstdMaxWindow is the largest zstd window accepted for payloads smaller than it
+const zstdMaxWindow = 8 * 1024 * 1024
+
+// grpcMaxPayloadSize is the payload limit of the gRPC server, which bounds the
+// zstd window of gRPC messages like readBody does for HTTP requests
+var grpcMaxPayloadSize atomic.Int64
+
+// errUnsupportedEncoding is returned for a Content-Encoding other than gzip or zstd
+var errUnsupportedEncoding = errors.New("unsupported content encoding")
+
+// readBody reads a request body of the given Content-Encoding. The compressed
+// and the decompressed size are both limited to maxSize.
+func readBody(body io.Reader, contentEncoding string, maxSize int64) ([]byte, error) {
+	limited := &limitedReader{r: body, remaining: maxSize}
+
+	var reader io.Reader
+	switch strings.ToLower(strings.TrimSpace(contentEncoding)) {
+	case "", "identity":
+		reader = limited
+	case "gzip", "x-gzip":
+		gz, err := gzip.NewReader(limited)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip body: %w", err)
+		}
+		defer gz.Close()
+		reader = gz
+	case "zstd":
+		zr, err := zstd.NewReader(limited, zstdDecoderOptions(maxSize)...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid zstd body: %w", err)
+		}
+		defer zr.Close()
+		reader = zr
+	default:
+		return nil, fmt.Errorf("%w %q", errUnsupportedEncoding, contentEncoding)
+	}
+
+	// Read one byte more than allowed to notice bodies that are too large
+	data, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+	if errors.Is(err, errPayloadTooLarge) || int64(len(data)) > maxSize {
+		return nil, errPayloadTooLarge
+	}
+	return data, err
+}
+
+// limitedReader fails with errPayloadTooLarge once more than remaining bytes were read
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, errPayloadTooLarge
+	}
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, errPayloadTooLarge
+	}
+	return n, err
+}
+
+// zstdCompressor adds zstd to the gRPC compressors (gzip is built in)
+type zstdCompressor struct{}
+
+func (zstdCompressor) Name() string {
+	return "zstd"
+}
+
+func (zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return zstd.NewWriter(w)
+}
+
+func (zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	zr, err := zstd.NewReader(r, zstdDecoderOptions(grpcMaxPayloadSize.Load())...)
+	if err != nil {
+		return nil, err
+	}
+	return zstdMessageReader{zr}, nil
+}
+
+// zstdDecoderOptions configures a zstd decoder for payloads of up to maxSize
+// bytes. Messages are small, so they are decoded synchronously. The window and
+// memory are limited so a crafted frame cannot claim huge buffers before the
+// payload limit applies; 8MB is the default window of zstd encoders.
+func zstdDecoderOptions(maxSize int64) []zstd.DOption {
+	limit := uint64(max(maxSize, zstdMaxWindow))
+	return []zstd.DOption{
+		zstd.WithDecoderConcurrency(1),
+		zstd.WithDecoderMaxWindow(limit),
+		zstd.WithDecoderMaxMemory(limit),
+	}
+}
+
+// zstdMessageReader releases its decoder once the message is read, as gRPC never closes it
+type zstdMessageReader struct {
+	decoder *zstd.Decoder
+}
+
+func (z zstdMessageReader) Read(p []byte) (int, error) {
+	n, err := z.decoder.Read(p)
+	if err != nil {
+		z.decoder.Close()
+	}
+	return n, err
+}
@@ -2,31 +2,61 @@ package otlpreceiver
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/tls"
+	"errors"
 	"fmt"
-	"io"
 	"log"
 	"net"
 	"net/http"
+	"strconv"
 	"sync"
 
 	otlpgrpc "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	tracegrpc "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
 	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
 
-// Receiver is an OTLP logs receiver
+// defaultMaxPayloadSize is the largest request accepted unless configured otherwise
+const defaultMaxPayloadSize = 4 * 1024 * 1024
+
+// Options configures the OTLP receiver
+type Options struct {
+	GRPCPort        int    // 0 disables the gRPC server
+	HTTPPort        int    // 0 disables the HTTP server
+	BindAddress     string // Address to listen on, empty for all interfaces
+	TLSCertFile     string // Serve gRPC and HTTP over TLS when both the certificate and key are set
+	TLSKeyFile      string
+	TLSClientCAFile string // Require client certificates signed by this CA
+	AuthToken       string // Require this bearer token, if set
+	AuthUser        string // Require these basic auth credentials, if set
+	AuthPassword    string
+	MaxPayloadSize  int // Largest request in bytes (after decompression), 0 for 4MB
+}
+
+// Record is a received log record with the resource and instrumentation scope
+// it was exported with. Records of the same request share their Resource and Scope.
+type Record struct {
+	Resource *resourcepb.Resource
+	Scope    *commonpb.InstrumentationScope
+	Log      *logspb.LogRecord
+}
+
+// Receiver is an OTLP logs receiver. Traces sent to it are kept in a span
+// index, so that logs can be linked to their spans.
 type Receiver struct {
-	grpcPort     int
-	httpPort     int
+	opts         Options
 	grpcServer   *grpc.Server
 	httpServer   *http.Server
 	grpcListener net.Listener
 	httpListener net.Listener
-	lineChan     chan string
+	recordChan   chan Record
+	spans        *SpanIndex
 	wg           sync.WaitGroup
 	ctx          context.Context
 	cancel       context.CancelFunc
@@ -39,14 +69,17 @@ type Receiver struct {
 }
 
 // NewReceiver creates a new OTLP receiver
-func NewReceiver(grpcPort, httpPort int) *Receiver {
+func NewReceiver(opts Options) *Receiver {
+	if opts.MaxPayloadSize <= 0 {
+		opts.MaxPayloadSize = defaultMaxPayloadSize
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	return &Receiver{
-		grpcPort: grpcPort,
-		httpPort: httpPort,
-		lineChan: make(chan string, 1000),
-		ctx:      ctx,
-		cancel:   cancel,
+		opts:       opts,
+		recordChan: make(chan Record, 1000),
+		spans:      NewSpanIndex(defaultMaxSpans),
+		ctx:        ctx,
+		cancel:     cancel,
 		jsonMarshaler: protojson.MarshalOptions{
 			UseProtoNames:   true,
 			EmitUnpopulated: false,
@@ -60,28 +93,46 @@ func NewReceiver(grpcPort, httpPort int) *Receiver {
 
 // Start starts the OTLP receiver
 func (r *Receiver) Start() error {
+	tlsConfig, err := r.opts.tlsConfig()
+	if err != nil {
+		return err
+	}
+	scheme := "plaintext"
+	if tlsConfig != nil {
+		scheme = "TLS"
+	}
+
 	// Start gRPC server
-	if r.grpcPort > 0 {
-		grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%d", r.grpcPort))
+	if r.opts.GRPCPort > 0 {
+		grpcListener, err := net.Listen("tcp", net.JoinHostPort(r.opts.BindAddress, strconv.Itoa(r.opts.GRPCPort)))
 		if err != nil {
-			return fmt.Errorf("failed to listen on gRPC port %d: %w", r.grpcPort, err)
+			return fmt.Errorf("failed to listen on gRPC port %d: %w", r.opts.GRPCPort, err)
 		}
 		r.grpcListener = grpcListener
 
-		// Create gRPC server with increased message size limits
-		r.grpcServer = grpc.NewServer(
-			grpc.MaxRecvMsgSize(4*1024*1024), // 4MB max receive message size
-			grpc.MaxSendMsgSize(4*1024*1024), // 4MB max send message size
-		)
+		// Create gRPC server with the configured message size limits
+		grpcMaxPayloadSize.Store(int64(r.opts.MaxPayloadSize))
+		serverOpts := []grpc.ServerOption{
+			grpc.MaxRecvMsgSize(r.opts.MaxPayloadSize),
+			grpc.MaxSendMsgSize(r.opts.MaxPayloadSize),
+		}
+		if tlsConfig != nil {
+			serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+		}
+		if r.opts.authRequired() {
+			serverOpts = append(serverOpts, grpc.UnaryInterceptor(r.authorizeGRPC))
+		}
+		r.grpcServer = grpc.NewServer(serverOpts...)
 
-		// Register the OTLP logs service
+		// Register the OTLP logs and trace services
 		otlpgrpc.RegisterLogsServiceServer(r.grpcServer, r)
+		tracegrpc.RegisterTraceServiceServer(r.grpcServer, &traceService{receiver: r})
 
 		// Start serving in a goroutine
 		r.wg.Add(1)
 		go func() {
 			defer r.wg.Done()
-			log.Printf("OTLP gRPC receiver listening on port %d", r.grpcPort)
+			log.Printf("OTLP gRPC receiver listening on %s (%s)", grpcListener.Addr(), scheme)
 			if err := r.grpcServer.Serve(grpcListener); err != nil && err != grpc.ErrServerStopped {
 				log.Printf("OTLP gRPC receiver serve error: %v", err)
 			}
@@ -89,16 +140,23 @@ func (r *Receiver) Start() error {
 	}
 
 	// Start HTTP server
-	if r.httpPort > 0 {
-		httpListener, err := net.Listen("tcp", fmt.Sprintf(":%d", r.httpPort))
+	if r.opts.HTTPPort > 0 {
+		httpListener, err := net.Listen("tcp", net.JoinHostPort(r.opts.BindAddress, strconv.Itoa(r.opts.HTTPPort)))
 		if err != nil {
-			return fmt.Errorf("failed to listen on HTTP port %d: %w", r.httpPort, err)
+			if r.grpcServer != nil {
+				r.grpcServer.Stop()
+			}
+			return fmt.Errorf("failed to listen on HTTP port %d: %w", r.opts.HTTPPort, err)
+		}
+		if tlsConfig != nil {
+			httpListener = tls.NewListener(httpListener, tlsConfig)
 		}
 		r.httpListener = httpListener
 
 		// Create HTTP server with routes
 		mux := http.NewServeMux()
-		mux.HandleFunc("/v1/logs", r.handleHTTPLogs)
+		mux.HandleFunc("/v1/logs", r.authorizeHTTP(r.handleHTTPLogs))
+		mux.HandleFunc("/v1/traces", r.authorizeHTTP(r.handleHTTPTraces))
 
 		r.httpServer = &http.Server{
 			Handler: mux,
@@ -108,7 +166,7 @@ func (r *Receiver) Start() error {
 		r.wg.Add(1)
 		go func() {
 			defer r.wg.Done()
-			log.Printf("OTLP HTTP receiver listening on port %d", r.httpPort)
+			log.Printf("OTLP HTTP receiver listening on %s (%s)", httpListener.Addr(), scheme)
 			if err := r.httpServer.Serve(httpListener); err != nil && err != http.ErrServerClosed {
 				log.Printf("OTLP HTTP receiver serve error: %v", err)
 			}
@@ -133,40 +191,36 @@ func (r *Receiver) Stop() {
 	}
 
 	r.wg.Wait()
-	close(r.lineChan)
+	close(r.recordChan)
+}
+
+// GetRecordChan returns the channel for receiving log records
+func (r *Receiver) GetRecordChan() <-chan Record {
+	return r.recordChan
 }
 
-// GetLineChan returns the channel for receiving log lines
-func (r *Receiver) GetLineChan() <-chan string {
-	return r.lineChan
+// Spans returns the index of received spans
+func (r *Receiver) Spans() *SpanIndex {
+	return r.spans
 }
 
 // Export implements the OTLP logs service Export method
 func (r *Receiver) Export(ctx context.Context, req *otlpgrpc.ExportLogsServiceRequest) (*otlpgrpc.ExportLogsServiceResponse, error) {
 	// Process each resource logs in the request
 	for _, resourceLogs := range req.ResourceLogs {
-		// Extract resource attributes
-		resourceAttrs := make(map[string]interface{})
-		if resourceLogs.Resource != nil {
-			for _, attr := range resourceLogs.Resource.Attributes {
-				resourceAttrs[attr.Key] = extractAttributeValue(attr.Value)
-			}
-		}
-
 		// Process each scope logs
 		for _, scopeLogs := range resourceLogs.ScopeLogs {
 			// Process each log record
 			for _, logRecord := range scopeLogs.LogRecords {
-				// Convert log record to JSON for processing
-				jsonLine, err := r.convertLogRecordToJSON(logRecord, resourceAttrs)
-				if err != nil {
-					log.Printf("Failed to convert log record to JSON: %v", err)
-					continue
+				record := Record{
+					Resource: resourceLogs.Resource,
+					Scope:    scopeLogs.Scope,
+					Log:      logRecord,
 				}
 
 				// Send to channel if not blocked
 				select {
-				case r.lineChan <- jsonLine:
+				case r.recordChan <- record:
 				case <-r.ctx.Done():
 					return nil, ctx.Err()
 				default:
@@ -183,57 +237,73 @@ func (r *Receiver) Export(ctx context.Context, req *otlpgrpc.ExportLogsServiceRe
 
 // handleHTTPLogs handles HTTP OTLP log requests
 func (r *Receiver) handleHTTPLogs(w http.ResponseWriter, req *http.Request) {
-	if req.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	var exportReq otlpgrpc.ExportLogsServiceRequest
+	if !r.readHTTPRequest(w, req, &exportReq) {
 		return
 	}
 
-	// Read the request body
-	body, err := io.ReadAll(req.Body)
+	// Process the logs using the existing Export method
+	_, err := r.Export(req.Context(), &exportReq)
 	if err != nil {
-		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	defer req.Body.Close()
 
-	// Create an ExportLogsServiceRequest
-	var exportReq otlpgrpc.ExportLogsServiceRequest
+	r.writeHTTPResponse(w, req, &otlpgrpc.ExportLogsServiceResponse{})
+}
+
+// readHTTPRequest reads the body of an HTTP OTLP request into exportReq. On
+// failure it writes the error response and returns false.
+func (r *Receiver) readHTTPRequest(w http.ResponseWriter, req *http.Request, exportReq proto.Message) bool {
+	if req.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+
+	// Read the request body, decompressing it as collectors compress with gzip by default
+	body, err := readBody(req.Body, req.Header.Get("Content-Encoding"), int64(r.opts.MaxPayloadSize))
+	defer req.Body.Close()
+	switch {
+	case errors.Is(err, errPayloadTooLarge):
+		http.Error(w, fmt.Sprintf("Request body exceeds %d bytes", r.opts.MaxPayloadSize), http.StatusRequestEntityTooLarge)
+		return false
+	case errors.Is(err, errUnsupportedEncoding):
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+		return false
+	case err != nil:
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return false
+	}
 
 	// Check Content-Type to determine how to parse
 	contentType := req.Header.Get("Content-Type")
 	if contentType == "application/x-protobuf" || contentType == "application/protobuf" {
 		// Parse binary protobuf
-		if err := proto.Unmarshal(body, &exportReq); err != nil {
+		if err := proto.Unmarshal(body, exportReq); err != nil {
 			http.Error(w, "Failed to unmarshal protobuf", http.StatusBadRequest)
-			return
+			return false
 		}
 	} else if contentType == "application/json" {
 		// Parse JSON
-		if err := r.jsonUnmarshaler.Unmarshal(body, &exportReq); err != nil {
+		if err := r.jsonUnmarshaler.Unmarshal(body, exportReq); err != nil {
 			http.Error(w, "Failed to unmarshal JSON", http.StatusBadRequest)
-			return
+			return false
 		}
 	} else {
 		// Try protobuf first, then JSON
-		if err := proto.Unmarshal(body, &exportReq); err != nil {
+		if err := proto.Unmarshal(body, exportReq); err != nil {
 			// Try JSON
-			if err := r.jsonUnmarshaler.Unmarshal(body, &exportReq); err != nil {
+			if err := r.jsonUnmarshaler.Unmarshal(body, exportReq); err != nil {
 				http.Error(w, "Failed to unmarshal request", http.StatusBadRequest)
-				return
+				return false
 			}
 		}
 	}
+	return true
+}
 
-	// Process the logs using the existing Export method
-	_, err = r.Export(req.Context(), &exportReq)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	// Return success response
-	response := &otlpgrpc.ExportLogsServiceResponse{}
-
+// writeHTTPResponse writes the success response of an HTTP OTLP request
+func (r *Receiver) writeHTTPResponse(w http.ResponseWriter, req *http.Request, response proto.Message) {
 	// Check Accept header to determine response format
 	accept := req.Header.Get("Accept")
 	if accept == "application/json" {
@@ -248,151 +318,3 @@ func (r *Receiver) handleHTTPLogs(w http.ResponseWriter, req *http.Request) {
 		w.Write(protoBytes)
 	}
 }
-
-// convertLogRecordToJSON converts an OTLP log record to JSON string
-func (r *Receiver) convertLogRecordToJSON(record *logspb.LogRecord, resourceAttrs map[string]interface{}) (string, error) {
-	// Build a simple JSON object that matches what the stdin processing expects
-	jsonMap := make(map[string]interface{})
-
-	// Add time - prefer TimeUnixNano, fallback to ObservedTimeUnixNano
-	if record.TimeUnixNano > 0 {
-		jsonMap["timeUnixNano"] = fmt.Sprintf("%d", record.TimeUnixNano)
-	} else if record.ObservedTimeUnixNano > 0 {
-		// Use observed time as fallback when actual time is missing
-		jsonMap["timeUnixNano"] = fmt.Sprintf("%d", record.ObservedTimeUnixNano)
-		jsonMap["observedTimeUnixNano"] = fmt.Sprintf("%d", record.ObservedTimeUnixNano)
-	}
-
-	// Add severity
-	if record.SeverityText != "" {
-		jsonMap["severityText"] = record.SeverityText
-	}
-	if record.SeverityNumber != 0 {
-		jsonMap["severityNumber"] = int(record.SeverityNumber)
-	}
-
-	// Add body (message)
-	if record.Body != nil {
-		switch v := record.Body.Value.(type) {
-		case *commonpb.AnyValue_StringValue:
-			jsonMap["body"] = map[string]interface{}{
-				"stringValue": v.StringValue,
-			}
-		case *commonpb.AnyValue_IntValue:
-			jsonMap["body"] = map[string]interface{}{
-				"intValue": fmt.Sprintf("%d", v.IntValue),
-			}
-		case *commonpb.AnyValue_DoubleValue:
-			jsonMap["body"] = map[string]interface{}{
-				"doubleValue": v.DoubleValue,
-			}
-		case *commonpb.AnyValue_BoolValue:
-			jsonMap["body"] = map[string]interface{}{
-				"boolValue": v.BoolValue,
-			}
-		}
-	}
-
-	// Merge resource and record attributes
-	// First add resource attributes
-	mergedAttrs := make([]map[string]interface{}, 0)
-	for key, value := range resourceAttrs {
-		attr := map[string]interface{}{
-			"key": key,
-			"value": map[string]interface{}{
-				"stringValue": fmt.Sprintf("%v", value),
-			},
-		}
-		mergedAttrs = append(mergedAttrs, attr)
-	}
-
-	// Then add record attributes (they can override resource attributes)
-	for _, attr := range record.Attributes {
-		attrMap := map[string]interface{}{
-			"key": attr.Key,
-		}
-
-		// Extract the value properly
-		if attr.Value != nil {
-			switch v := attr.Value.Value.(type) {
-			case *commonpb.AnyValue_StringValue:
-				attrMap["value"] = map[string]interface{}{
-					"stringValue": v.StringValue,
-				}
-			case *commonpb.AnyValue_IntValue:
-				attrMap["value"] = map[string]interface{}{
-					"intValue": fmt.Sprintf("%d", v.IntValue),
-				}
-			case *commonpb.AnyValue_DoubleValue:
-				attrMap["value"] = map[string]interface{}{
-					"doubleValue": v.DoubleValue,
-				}
-			case *commonpb.AnyValue_BoolValue:
-				attrMap["value"] = map[string]interface{}{
-					"boolValue": v.BoolValue,
-				}
-			}
-		}
-
-		mergedAttrs = append(mergedAttrs, attrMap)
-	}
-
-	// Add merged attributes to the JSON
-	if len(mergedAttrs) > 0 {
-		jsonMap["attributes"] = mergedAttrs
-	}
-
-	// Add trace and span IDs if present
-	if len(record.TraceId) > 0 {
-		jsonMap["traceId"] = fmt.Sprintf("%x", record.TraceId)
-	}
-	if len(record.SpanId) > 0 {
-		jsonMap["spanId"] = fmt.Sprintf("%x", record.SpanId)
-	}
-
-	// Convert to JSON string
-	finalJSON, err := json.Marshal(jsonMap)
-	if err != nil {
-		return "", err
-	}
-
-	return string(finalJSON), nil
-}
-
-// extractAttributeValue extracts the value from an AnyValue
-func extractAttributeValue(v *commonpb.AnyValue) interface{} {
-	if v == nil {
-		return nil
-	}
-
-	switch val := v.Value.(type) {
-	case *commonpb.AnyValue_StringValue:
-		return val.StringValue
-	case *commonpb.AnyValue_BoolValue:
-		return val.BoolValue
-	case *commonpb.AnyValue_IntValue:
-		return val.IntValue
-	case *commonpb.AnyValue_DoubleValue:
-		return val.DoubleValue
-	case *commonpb.AnyValue_ArrayValue:
-		if val.ArrayValue != nil {
-			arr := make([]interface{}, len(val.ArrayValue.Values))
-			for i, v := range val.ArrayValue.Values {
-				arr[i] = extractAttributeValue(v)
-			}
-			return arr
-		}
-	case *commonpb.AnyValue_KvlistValue:
-		if val.KvlistValue != nil {
-			m := make(map[string]interface{})
-			for _, kv := range val.KvlistValue.Values {
-				m[kv.Key] = extractAttributeValue(kv.Value)
-			}
-			return m
-		}
-	case *commonpb.AnyValue_BytesValue:
-		return val.BytesValue
-	}
-
-	return nil
-}
@@ -0,0 +1,117 @@
+package otlpreceiver
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tlsConfig returns the TLS configuration of both servers, or nil when TLS is off.
+// With a client CA, clients have to present a certificate signed by it.
+func (o Options) tlsConfig() (*tls.Config, error) {
+	if o.TLSCertFile == "" && o.TLSKeyFile == "" {
+		if o.TLSClientCAFile != "" {
+			return nil, fmt.Errorf("OTLP TLS client CA requires a TLS certificate and key")
+		}
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(o.TLSCertFile, o.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OTLP TLS certificate: %w", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if o.TLSClientCAFile != "" {
+		pem, err := os.ReadFile(o.TLSClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OTLP TLS client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in OTLP TLS client CA %s", o.TLSClientCAFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return config, nil
+}
+
+// authRequired reports whether requests have to carry a bearer token or basic auth credentials
+func (o Options) authRequired() bool {
+	return o.AuthToken != "" || o.AuthUser != ""
+}
+
+// authorized checks the value of an Authorization header against the
+// configured bearer token or basic auth credentials
+func (o Options) authorized(header string) bool {
+	if !o.authRequired() {
+		return true
+	}
+
+	scheme, credentials, _ := strings.Cut(header, " ")
+	switch {
+	case strings.EqualFold(scheme, "Bearer") && o.AuthToken != "":
+		return secureEqual(credentials, o.AuthToken)
+
+	case strings.EqualFold(scheme, "Basic") && o.AuthUser != "":
+		decoded, err := base64.StdEncoding.DecodeString(credentials)
+		if err != nil {
+			return false
+		}
+		user, password, ok := strings.Cut(string(decoded), ":")
+		// Check both so that the time taken does not tell which one was wrong
+		userOK := secureEqual(user, o.AuthUser)
+		passwordOK := secureEqual(password, o.AuthPassword)
+		return ok && userOK && passwordOK
+	}
+	return false
+}
+
+// secureEqual compares secrets in constant time
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// authorizeHTTP rejects HTTP requests without valid credentials
+func (r *Receiver) authorizeHTTP(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if !r.opts.authorized(req.Header.Get("Authorization")) {
+			if r.opts.AuthUser != "" {
+				w.Header().Set("WWW-Authenticate", `Basic realm="gonzo"`)
+			}
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, req)
+	}
+}
+
+// authorizeGRPC rejects gRPC calls without valid credentials in their metadata
+func (r *Receiver) authorizeGRPC(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var header string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			header = values[0]
+		}
+	}
+	if !r.opts.authorized(header) {
+		return nil, status.Error(codes.Unauthenticated, "invalid or missing credentials")
+	}
+	return handler(ctx, req)
+}
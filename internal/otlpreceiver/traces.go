@@ -0,0 +1,137 @@
+package otlpreceiver
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	tracegrpc "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// defaultMaxSpans is the number of spans the span index keeps
+const defaultMaxSpans = 20000
+
+// Span is a received span, reduced to what is needed to link logs to it.
+// IDs are lowercase hex strings.
+type Span struct {
+	TraceID      string
+	SpanID       string
+	ParentSpanID string
+	Name         string
+	Service      string
+	Start        time.Time
+	End          time.Time
+	Error        bool
+}
+
+// SpanIndex keeps the spans of the most recent traces. Once it holds more than
+// its maximum number of spans, the oldest traces are dropped as a whole.
+type SpanIndex struct {
+	mu       sync.RWMutex
+	maxSpans int
+	spans    int
+	traces   map[string][]Span
+	order    []string // Trace IDs, oldest first
+}
+
+// NewSpanIndex creates a span index keeping at most maxSpans spans
+func NewSpanIndex(maxSpans int) *SpanIndex {
+	return &SpanIndex{
+		maxSpans: maxSpans,
+		traces:   make(map[string][]Span),
+	}
+}
+
+// Add adds a span to its trace
+func (s *SpanIndex) Add(span Span) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.traces[span.TraceID]; !ok {
+		s.order = append(s.order, span.TraceID)
+	}
+	s.traces[span.TraceID] = append(s.traces[span.TraceID], span)
+	s.spans++
+
+	// Drop the oldest traces, but never the one just added to
+	for s.spans > s.maxSpans && len(s.order) > 1 {
+		oldest := s.order[0]
+		s.order = s.order[1:]
+		s.spans -= len(s.traces[oldest])
+		delete(s.traces, oldest)
+	}
+}
+
+// Trace returns the spans of a trace ordered by start time, or nil if none were received
+func (s *SpanIndex) Trace(traceID string) []Span {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	spans := append([]Span(nil), s.traces[traceID]...)
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].Start.Before(spans[j].Start)
+	})
+	return spans
+}
+
+// traceService implements the OTLP trace service. It is a type of its own as
+// the logs and trace services both have an Export method.
+type traceService struct {
+	receiver *Receiver
+
+	tracegrpc.UnimplementedTraceServiceServer
+}
+
+// Export implements the OTLP trace service Export method
+func (t *traceService) Export(ctx context.Context, req *tracegrpc.ExportTraceServiceRequest) (*tracegrpc.ExportTraceServiceResponse, error) {
+	t.receiver.indexSpans(req.ResourceSpans)
+	return &tracegrpc.ExportTraceServiceResponse{}, nil
+}
+
+// indexSpans adds received spans to the span index
+func (r *Receiver) indexSpans(resourceSpans []*tracepb.ResourceSpans) {
+	for _, rs := range resourceSpans {
+		service := ""
+		if rs.Resource != nil {
+			for _, attr := range rs.Resource.Attributes {
+				if attr.Key == "service.name" {
+					service = attr.Value.GetStringValue()
+				}
+			}
+		}
+
+		for _, scopeSpans := range rs.ScopeSpans {
+			for _, span := range scopeSpans.Spans {
+				if len(span.TraceId) == 0 || len(span.SpanId) == 0 {
+					continue
+				}
+				r.spans.Add(Span{
+					TraceID:      hex.EncodeToString(span.TraceId),
+					SpanID:       hex.EncodeToString(span.SpanId),
+					ParentSpanID: hex.EncodeToString(span.ParentSpanId),
+					Name:         span.Name,
+					Service:      service,
+					Start:        time.Unix(0, int64(span.StartTimeUnixNano)),
+					End:          time.Unix(0, int64(span.EndTimeUnixNano)),
+					Error:        span.Status.GetCode() == tracepb.Status_STATUS_CODE_ERROR,
+				})
+			}
+		}
+	}
+}
+
+// handleHTTPTraces handles HTTP OTLP trace requests
+func (r *Receiver) handleHTTPTraces(w http.ResponseWriter, req *http.Request) {
+	var exportReq tracegrpc.ExportTraceServiceRequest
+	if !r.readHTTPRequest(w, req, &exportReq) {
+		return
+	}
+
+	r.indexSpans(exportReq.ResourceSpans)
+
+	r.writeHTTPResponse(w, req, &tracegrpc.ExportTraceServiceResponse{})
+}
@@ -0,0 +1,519 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/control-theory/gonzo/internal/drain3"
+	"github.com/control-theory/gonzo/internal/memory"
+)
+
+// ReportFormat selects how a batch analysis report is rendered
+type ReportFormat string
+
+const (
+	ReportFormatText     ReportFormat = "text"
+	ReportFormatJSON     ReportFormat = "json"
+	ReportFormatMarkdown ReportFormat = "markdown"
+)
+
+// ParseReportFormat converts a user supplied format name into a ReportFormat
+func ParseReportFormat(name string) (ReportFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "text", "txt":
+		return ReportFormatText, nil
+	case "json":
+		return ReportFormatJSON, nil
+	case "markdown", "md":
+		return ReportFormatMarkdown, nil
+	default:
+		return "", fmt.Errorf("unknown report format %q (expected text, json or markdown)", name)
+	}
+}
+
+// ReportCount is a named counter with its share of the total
+type ReportCount struct {
+	Name       string  `json:"name"`
+	Count      int64   `json:"count"`
+	Percentage float64 `json:"percentage"`
+}
+
+// ReportAttribute summarizes a single attribute key
+type ReportAttribute struct {
+	Key          string        `json:"key"`
+	UniqueValues int           `json:"unique_values"`
+	TotalCount   int64         `json:"total_count"`
+	TopValues    []ReportCount `json:"top_values"`
+}
+
+// ReportNumeric summarizes the values of an attribute that holds numbers
+type ReportNumeric struct {
+	Key   string  `json:"key"`
+	Count int64   `json:"count"`
+	Min   float64 `json:"min"`
+	P50   float64 `json:"p50"`
+	P90   float64 `json:"p90"`
+	P99   float64 `json:"p99"`
+	Max   float64 `json:"max"`
+	Mean  float64 `json:"mean"`
+}
+
+// ReportPattern is a Drain3 log template with its frequency
+type ReportPattern struct {
+	Template   string  `json:"template"`
+	Count      int64   `json:"count"`
+	Percentage float64 `json:"percentage"`
+}
+
+// Report is the result of analyzing a finite log input
+type Report struct {
+	GeneratedAt    time.Time         `json:"generated_at"`
+	Sources        []string          `json:"sources"`
+	LinesProcessed int               `json:"lines_processed"`
+	Entries        int64             `json:"entries"`
+	FirstTimestamp *time.Time        `json:"first_timestamp,omitempty"`
+	LastTimestamp  *time.Time        `json:"last_timestamp,omitempty"`
+	Severities     []ReportCount     `json:"severities"`
+	Services       []ReportCount     `json:"services"`
+	Patterns       []ReportPattern   `json:"patterns"`
+	TopWords       []ReportCount     `json:"top_words"`
+	TopPhrases     []ReportCount     `json:"top_phrases"`
+	Attributes     []ReportAttribute `json:"attributes"`
+	Numeric        []ReportNumeric   `json:"numeric_attributes"`
+}
+
+// severityOrder is the display order for well-known severities (most severe first)
+var severityOrder = []string{"FATAL", "CRITICAL", "ERROR", "WARN", "INFO", "DEBUG", "TRACE", "UNKNOWN"}
+
+// ReportBuilder accumulates log entries and produces a Report
+type ReportBuilder struct {
+	drain      *drain3.Drain
+	severities map[string]int64
+	services   map[string]int64
+	entries    int64
+	first      time.Time
+	last       time.Time
+}
+
+// NewReportBuilder creates a report builder with an empty pattern tree
+func NewReportBuilder() *ReportBuilder {
+	// Same clustering settings as the dashboard, but keep more clusters
+	// since the whole input is summarized at once
+	config := &drain3.Config{
+		Depth:        4,
+		SimilarityTh: 0.5,
+		MaxChildren:  50,
+		MaxClusters:  1000,
+	}
+
+	return &ReportBuilder{
+		drain:      drain3.New(config),
+		severities: make(map[string]int64),
+		services:   make(map[string]int64),
+	}
+}
+
+// AddEntry records a single processed log entry
+func (rb *ReportBuilder) AddEntry(severity, service, message string, timestamp time.Time) {
+	rb.entries++
+
+	severity = strings.ToUpper(strings.TrimSpace(severity))
+	if severity == "" {
+		severity = "UNKNOWN"
+	}
+	rb.severities[severity]++
+
+	if service != "" {
+		rb.services[service]++
+	}
+
+	if !timestamp.IsZero() {
+		if rb.first.IsZero() || timestamp.Before(rb.first) {
+			rb.first = timestamp
+		}
+		if timestamp.After(rb.last) {
+			rb.last = timestamp
+		}
+	}
+
+	if rb.drain != nil && strings.TrimSpace(message) != "" {
+		_, _, _ = rb.drain.AddLogMessage(message)
+	}
+}
+
+// Build assembles the final report, keeping at most limit items per section (0 means all)
+func (rb *ReportBuilder) Build(sources []string, linesProcessed int, snapshot *memory.FrequencySnapshot, limit int) *Report {
+	report := &Report{
+		GeneratedAt:    time.Now(),
+		Sources:        sources,
+		LinesProcessed: linesProcessed,
+		Entries:        rb.entries,
+		Severities:     rb.buildSeverities(),
+		Services:       trimCounts(sortedCounts(rb.services, rb.entries), limit),
+		Patterns:       rb.buildPatterns(limit),
+	}
+	if report.Sources == nil {
+		report.Sources = []string{}
+	}
+
+	if !rb.first.IsZero() {
+		first, last := rb.first, rb.last
+		report.FirstTimestamp = &first
+		report.LastTimestamp = &last
+	}
+
+	report.TopWords = []ReportCount{}
+	report.TopPhrases = []ReportCount{}
+	report.Attributes = []ReportAttribute{}
+	report.Numeric = []ReportNumeric{}
+	if snapshot == nil {
+		return report
+	}
+
+	for _, word := range snapshot.Words {
+		report.TopWords = append(report.TopWords, ReportCount{Name: word.Term, Count: word.Count})
+	}
+	report.TopWords = trimCounts(report.TopWords, limit)
+
+	for _, phrase := range snapshot.Phrases {
+		report.TopPhrases = append(report.TopPhrases, ReportCount{Name: phrase.Term, Count: phrase.Count})
+	}
+	report.TopPhrases = trimCounts(report.TopPhrases, limit)
+
+	for _, attr := range snapshot.Attributes {
+		if limit > 0 && len(report.Attributes) >= limit {
+			break
+		}
+		values := sortedCounts(attr.Values, attr.TotalCount)
+		report.Attributes = append(report.Attributes, ReportAttribute{
+			Key:          attr.Key,
+			UniqueValues: attr.UniqueValueCount,
+			TotalCount:   attr.TotalCount,
+			TopValues:    trimCounts(values, 5),
+		})
+	}
+
+	report.Numeric = buildNumeric(snapshot.Attributes, limit)
+
+	return report
+}
+
+// buildNumeric summarizes the numeric attributes, most values first
+func buildNumeric(attributes []*memory.AttributeStatsEntry, limit int) []ReportNumeric {
+	numeric := []ReportNumeric{}
+	for _, attr := range attributes {
+		if !attr.IsNumeric() {
+			continue
+		}
+		values := attr.Numeric
+		numeric = append(numeric, ReportNumeric{
+			Key:   attr.Key,
+			Count: values.Count,
+			Min:   values.Min,
+			P50:   values.Quantile(0.5),
+			P90:   values.Quantile(0.9),
+			P99:   values.Quantile(0.99),
+			Max:   values.Max,
+			Mean:  values.Mean(),
+		})
+	}
+
+	sort.SliceStable(numeric, func(i, j int) bool {
+		if numeric[i].Count != numeric[j].Count {
+			return numeric[i].Count > numeric[j].Count
+		}
+		return numeric[i].Key < numeric[j].Key
+	})
+	if limit > 0 && len(numeric) > limit {
+		numeric = numeric[:limit]
+	}
+	return numeric
+}
+
+// buildSeverities orders severities from most to least severe
+func (rb *ReportBuilder) buildSeverities() []ReportCount {
+	result := make([]ReportCount, 0, len(rb.severities))
+	seen := make(map[string]bool)
+	for _, severity := range severityOrder {
+		if count, ok := rb.severities[severity]; ok {
+			result = append(result, ReportCount{Name: severity, Count: count, Percentage: percentage(count, rb.entries)})
+			seen[severity] = true
+		}
+	}
+
+	// Any non-standard severities go last, by count
+	var others []ReportCount
+	for severity, count := range rb.severities {
+		if !seen[severity] {
+			others = append(others, ReportCount{Name: severity, Count: count, Percentage: percentage(count, rb.entries)})
+		}
+	}
+	sortCounts(others)
+
+	return append(result, others...)
+}
+
+// buildPatterns converts Drain3 clusters into report patterns sorted by count
+func (rb *ReportBuilder) buildPatterns(limit int) []ReportPattern {
+	patterns := []ReportPattern{}
+	if rb.drain == nil {
+		return patterns
+	}
+
+	var total int64
+	for _, cluster := range rb.drain.GetClusters() {
+		if cluster == nil || len(cluster.LogTemplateTokens) == 0 {
+			continue
+		}
+		patterns = append(patterns, ReportPattern{
+			Template: strings.Join(cluster.LogTemplateTokens, " "),
+			Count:    cluster.Size,
+		})
+		total += cluster.Size
+	}
+
+	sort.Slice(patterns, func(i, j int) bool {
+		if patterns[i].Count == patterns[j].Count {
+			return patterns[i].Template < patterns[j].Template
+		}
+		return patterns[i].Count > patterns[j].Count
+	})
+
+	for i := range patterns {
+		patterns[i].Percentage = percentage(patterns[i].Count, total)
+	}
+
+	if limit > 0 && len(patterns) > limit {
+		patterns = patterns[:limit]
+	}
+	return patterns
+}
+
+// Write renders the report in the requested format
+func (r *Report) Write(w io.Writer, format ReportFormat) error {
+	switch format {
+	case ReportFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	case ReportFormatMarkdown:
+		return r.writeMarkdown(w)
+	default:
+		return r.writeText(w)
+	}
+}
+
+// writeText renders the report as plain text with bar charts
+func (r *Report) writeText(w io.Writer) error {
+	var b strings.Builder
+	rule := strings.Repeat("═", 63)
+	thin := strings.Repeat("─", 63)
+
+	fmt.Fprintf(&b, "%s\n", rule)
+	fmt.Fprintf(&b, "Gonzo Log Report - %s\n", r.GeneratedAt.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(&b, "%s\n", rule)
+	if len(r.Sources) > 0 {
+		fmt.Fprintf(&b, "Sources:         %s\n", strings.Join(r.Sources, ", "))
+	}
+	fmt.Fprintf(&b, "Lines processed: %d\n", r.LinesProcessed)
+	fmt.Fprintf(&b, "Log entries:     %d\n", r.Entries)
+	if r.FirstTimestamp != nil {
+		fmt.Fprintf(&b, "Time range:      %s → %s (%s)\n",
+			r.FirstTimestamp.Format(time.RFC3339), r.LastTimestamp.Format(time.RFC3339),
+			r.LastTimestamp.Sub(*r.FirstTimestamp).Round(time.Second))
+	}
+	b.WriteString("\n")
+
+	writeTextCounts := func(title string, counts []ReportCount, nameWidth int) {
+		if len(counts) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "%s\n%s\n", title, thin)
+		maxCount := counts[0].Count
+		for _, c := range counts {
+			if c.Count > maxCount {
+				maxCount = c.Count
+			}
+		}
+		for i, c := range counts {
+			fmt.Fprintf(&b, "%2d. %-*s │%s│ %d\n", i+1, nameWidth, truncate(c.Name, nameWidth), renderBar(c.Count, maxCount, 20), c.Count)
+		}
+		b.WriteString("\n")
+	}
+
+	writeTextCounts("SEVERITY DISTRIBUTION:", r.Severities, 10)
+	writeTextCounts("TOP SERVICES:", r.Services, 30)
+
+	if len(r.Patterns) > 0 {
+		fmt.Fprintf(&b, "TOP PATTERNS:\n%s\n", thin)
+		for i, p := range r.Patterns {
+			fmt.Fprintf(&b, "%2d. [%d, %.1f%%] %s\n", i+1, p.Count, p.Percentage, p.Template)
+		}
+		b.WriteString("\n")
+	}
+
+	writeTextCounts("TOP WORDS:", r.TopWords, 20)
+	writeTextCounts("TOP PHRASES:", r.TopPhrases, 33)
+
+	if len(r.Attributes) > 0 {
+		fmt.Fprintf(&b, "TOP ATTRIBUTES:\n%s\n", thin)
+		for i, attr := range r.Attributes {
+			fmt.Fprintf(&b, "%2d. %-30s %d unique values (%d total)\n", i+1, truncate(attr.Key, 30), attr.UniqueValues, attr.TotalCount)
+			for _, v := range attr.TopValues {
+				fmt.Fprintf(&b, "      %-40s %d\n", truncate(v.Name, 40), v.Count)
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	if len(r.Numeric) > 0 {
+		fmt.Fprintf(&b, "NUMERIC ATTRIBUTES:\n%s\n", thin)
+		fmt.Fprintf(&b, "    %-22s %8s %8s %8s %8s %8s %8s\n", "", "min", "p50", "p90", "p99", "max", "mean")
+		for i, n := range r.Numeric {
+			fmt.Fprintf(&b, "%2d. %-22s %8s %8s %8s %8s %8s %8s\n", i+1, truncate(n.Key, 22),
+				memory.FormatNumber(n.Min), memory.FormatNumber(n.P50), memory.FormatNumber(n.P90),
+				memory.FormatNumber(n.P99), memory.FormatNumber(n.Max), memory.FormatNumber(n.Mean))
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "%s\n", rule)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMarkdown renders the report as GitHub-flavored Markdown
+func (r *Report) writeMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Gonzo Log Report\n\n")
+	fmt.Fprintf(&b, "_Generated %s_\n\n", r.GeneratedAt.Format(time.RFC3339))
+	if len(r.Sources) > 0 {
+		fmt.Fprintf(&b, "- **Sources:** %s\n", escapeMarkdown(strings.Join(r.Sources, ", ")))
+	}
+	fmt.Fprintf(&b, "- **Lines processed:** %d\n", r.LinesProcessed)
+	fmt.Fprintf(&b, "- **Log entries:** %d\n", r.Entries)
+	if r.FirstTimestamp != nil {
+		fmt.Fprintf(&b, "- **Time range:** %s → %s\n", r.FirstTimestamp.Format(time.RFC3339), r.LastTimestamp.Format(time.RFC3339))
+	}
+	b.WriteString("\n")
+
+	writeMarkdownCounts := func(title, column string, counts []ReportCount, withPercentage bool) {
+		if len(counts) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "## %s\n\n", title)
+		if withPercentage {
+			fmt.Fprintf(&b, "| %s | Count | %% |\n|---|---:|---:|\n", column)
+		} else {
+			fmt.Fprintf(&b, "| %s | Count |\n|---|---:|\n", column)
+		}
+		for _, c := range counts {
+			if withPercentage {
+				fmt.Fprintf(&b, "| %s | %d | %.1f |\n", escapeMarkdown(c.Name), c.Count, c.Percentage)
+			} else {
+				fmt.Fprintf(&b, "| %s | %d |\n", escapeMarkdown(c.Name), c.Count)
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	writeMarkdownCounts("Severity", "Severity", r.Severities, true)
+	writeMarkdownCounts("Services", "Service", r.Services, true)
+
+	if len(r.Patterns) > 0 {
+		fmt.Fprintf(&b, "## Patterns\n\n| Count | %% | Template |\n|---:|---:|---|\n")
+		for _, p := range r.Patterns {
+			fmt.Fprintf(&b, "| %d | %.1f | `%s` |\n", p.Count, p.Percentage, strings.ReplaceAll(p.Template, "|", "\\|"))
+		}
+		b.WriteString("\n")
+	}
+
+	writeMarkdownCounts("Top Words", "Word", r.TopWords, false)
+	writeMarkdownCounts("Top Phrases", "Phrase", r.TopPhrases, false)
+
+	if len(r.Attributes) > 0 {
+		fmt.Fprintf(&b, "## Attributes\n\n| Key | Unique Values | Total | Top Values |\n|---|---:|---:|---|\n")
+		for _, attr := range r.Attributes {
+			values := make([]string, 0, len(attr.TopValues))
+			for _, v := range attr.TopValues {
+				values = append(values, fmt.Sprintf("%s (%d)", escapeMarkdown(v.Name), v.Count))
+			}
+			fmt.Fprintf(&b, "| %s | %d | %d | %s |\n", escapeMarkdown(attr.Key), attr.UniqueValues, attr.TotalCount, strings.Join(values, ", "))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(r.Numeric) > 0 {
+		fmt.Fprintf(&b, "## Numeric Attributes\n\n| Key | Count | Min | p50 | p90 | p99 | Max | Mean |\n|---|---:|---:|---:|---:|---:|---:|---:|\n")
+		for _, n := range r.Numeric {
+			fmt.Fprintf(&b, "| %s | %d | %s | %s | %s | %s | %s | %s |\n", escapeMarkdown(n.Key), n.Count,
+				memory.FormatNumber(n.Min), memory.FormatNumber(n.P50), memory.FormatNumber(n.P90),
+				memory.FormatNumber(n.P99), memory.FormatNumber(n.Max), memory.FormatNumber(n.Mean))
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// sortedCounts converts a count map into a slice sorted by count
+func sortedCounts(counts map[string]int64, total int64) []ReportCount {
+	result := make([]ReportCount, 0, len(counts))
+	for name, count := range counts {
+		result = append(result, ReportCount{Name: name, Count: count, Percentage: percentage(count, total)})
+	}
+	sortCounts(result)
+	return result
+}
+
+// sortCounts sorts by count (descending), then by name
+func sortCounts(counts []ReportCount) {
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count == counts[j].Count {
+			return counts[i].Name < counts[j].Name
+		}
+		return counts[i].Count > counts[j].Count
+	})
+}
+
+// trimCounts keeps at most limit entries (0 means all)
+func trimCounts(counts []ReportCount, limit int) []ReportCount {
+	if limit > 0 && len(counts) > limit {
+		return counts[:limit]
+	}
+	return counts
+}
+
+func percentage(count, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) * 100.0 / float64(total)
+}
+
+func truncate(s string, maxLen int) string {
+	if len(s) > maxLen && maxLen > 3 {
+		// Cut at a character boundary, so that multi-byte characters stay whole
+		cut := maxLen - 3
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		return s[:cut] + "..."
+	}
+	return s
+}
+
+// escapeMarkdown escapes characters that would break a Markdown table cell
+func escapeMarkdown(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return s
+}
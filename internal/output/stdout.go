@@ -117,6 +117,11 @@ func (sf *StdoutFormatter) ResetInterval() {
 }
 
 func (sf *StdoutFormatter) createBar(count, maxCount int64, width int) string {
+	return renderBar(count, maxCount, width)
+}
+
+// renderBar draws a horizontal bar of the given width scaled to maxCount
+func renderBar(count, maxCount int64, width int) string {
 	if maxCount == 0 {
 		return strings.Repeat(" ", width)
 	}
@@ -0,0 +1,323 @@
+package syslogreceiver
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+)
+
+// Attribute keys set on every syslog record
+const (
+	AttrFacility = "syslog.facility"
+	AttrSeverity = "syslog.severity"
+	AttrProcID   = "syslog.procid"
+	AttrMsgID    = "syslog.msgid"
+	AttrHost     = "host"         // Hostname of the sender (or its address when missing)
+	AttrAppName  = "service.name" // APP-NAME (RFC 5424) or TAG (RFC 3164)
+
+	// Structured data params are stored as syslog.sd.<SD-ID>.<PARAM-NAME>
+	attrStructuredDataPrefix = "syslog.sd."
+)
+
+// defaultPriority is used for messages without a PRI part (user.notice, RFC 3164 section 4.3.3)
+const defaultPriority = 13
+
+var facilityNames = []string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news",
+	"uucp", "cron", "authpriv", "ftp", "ntp", "security", "console", "solaris-cron",
+	"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+}
+
+// syslogSeverities maps the PRI severity (0-7) to its name and the OTLP severity
+var syslogSeverities = []struct {
+	name   string
+	text   string
+	number logspb.SeverityNumber
+}{
+	{"emerg", "FATAL", logspb.SeverityNumber_SEVERITY_NUMBER_FATAL4},
+	{"alert", "FATAL", logspb.SeverityNumber_SEVERITY_NUMBER_FATAL3},
+	{"crit", "CRITICAL", logspb.SeverityNumber_SEVERITY_NUMBER_FATAL2},
+	{"err", "ERROR", logspb.SeverityNumber_SEVERITY_NUMBER_ERROR},
+	{"warning", "WARN", logspb.SeverityNumber_SEVERITY_NUMBER_WARN},
+	{"notice", "INFO", logspb.SeverityNumber_SEVERITY_NUMBER_INFO2},
+	{"info", "INFO", logspb.SeverityNumber_SEVERITY_NUMBER_INFO},
+	{"debug", "DEBUG", logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG},
+}
+
+// message is a parsed syslog message
+type message struct {
+	priority       int
+	timestamp      time.Time // Zero when the message carries no (valid) timestamp
+	hostname       string
+	appName        string
+	procID         string
+	msgID          string
+	structuredData []sdElement
+	text           string
+}
+
+// sdElement is an RFC 5424 structured data element
+type sdElement struct {
+	id     string
+	params []sdParam
+}
+
+type sdParam struct {
+	name  string
+	value string
+}
+
+// parseMessage parses an RFC 5424 or RFC 3164 message. Anything that does not
+// follow either format is kept as the message text.
+func parseMessage(line string, received time.Time) message {
+	line = strings.TrimRight(line, "\r\n\x00")
+
+	msg := message{priority: defaultPriority}
+	rest := line
+	if priority, n, ok := parsePriority(line); ok {
+		msg.priority = priority
+		rest = line[n:]
+	}
+
+	if strings.HasPrefix(rest, "1 ") {
+		if parseRFC5424(rest[2:], &msg) {
+			return msg
+		}
+		msg = message{priority: msg.priority}
+	}
+
+	parseRFC3164(rest, received, &msg)
+	return msg
+}
+
+// parsePriority parses the <PRI> prefix and returns the number of bytes it used
+func parsePriority(line string) (priority, n int, ok bool) {
+	if len(line) < 3 || line[0] != '<' {
+		return 0, 0, false
+	}
+	end := strings.IndexByte(line, '>')
+	if end < 2 || end > 4 {
+		return 0, 0, false
+	}
+	priority, err := strconv.Atoi(line[1:end])
+	if err != nil || priority < 0 || priority > 191 {
+		return 0, 0, false
+	}
+	return priority, end + 1, true
+}
+
+// parseRFC5424 parses everything after "<PRI>1 ":
+// TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA [MSG]
+func parseRFC5424(rest string, msg *message) bool {
+	var header [5]string
+	for i := range header {
+		field, remaining, ok := strings.Cut(rest, " ")
+		if !ok {
+			return false
+		}
+		header[i] = nilValue(field)
+		rest = remaining
+	}
+
+	if header[0] != "" {
+		ts, err := time.Parse(time.RFC3339Nano, header[0])
+		if err != nil {
+			return false
+		}
+		msg.timestamp = ts
+	}
+	msg.hostname, msg.appName, msg.procID, msg.msgID = header[1], header[2], header[3], header[4]
+
+	if strings.HasPrefix(rest, "-") {
+		rest = rest[1:]
+	} else {
+		elements, remaining, ok := parseStructuredData(rest)
+		if !ok {
+			return false
+		}
+		msg.structuredData = elements
+		rest = remaining
+	}
+
+	rest = strings.TrimPrefix(rest, " ")
+	msg.text = strings.TrimPrefix(rest, "\ufeff") // UTF-8 BOM marks a UTF-8 message
+	return true
+}
+
+// parseStructuredData parses [SD-ID PARAM="value" ...] elements
+func parseStructuredData(rest string) ([]sdElement, string, bool) {
+	var elements []sdElement
+
+	for strings.HasPrefix(rest, "[") {
+		rest = rest[1:]
+		end := strings.IndexAny(rest, " ]")
+		if end <= 0 {
+			return nil, "", false
+		}
+		element := sdElement{id: rest[:end]}
+		rest = rest[end:]
+
+		for strings.HasPrefix(rest, " ") {
+			rest = strings.TrimLeft(rest, " ")
+			name, remaining, ok := strings.Cut(rest, "=\"")
+			if !ok || name == "" {
+				return nil, "", false
+			}
+
+			var value strings.Builder
+			i := 0
+			for ; i < len(remaining) && remaining[i] != '"'; i++ {
+				// Only \" \\ and \] are escapes, any other backslash is kept
+				if remaining[i] == '\\' && i+1 < len(remaining) && strings.IndexByte(`"\]`, remaining[i+1]) >= 0 {
+					i++
+				}
+				value.WriteByte(remaining[i])
+			}
+			if i >= len(remaining) {
+				return nil, "", false
+			}
+			element.params = append(element.params, sdParam{name: name, value: value.String()})
+			rest = remaining[i+1:]
+		}
+
+		if !strings.HasPrefix(rest, "]") {
+			return nil, "", false
+		}
+		rest = rest[1:]
+		elements = append(elements, element)
+	}
+
+	return elements, rest, len(elements) > 0
+}
+
+// rfc3164Layouts are the BSD timestamp formats, with and without a year
+var rfc3164Layouts = []string{"Jan _2 15:04:05", "Jan _2 2006 15:04:05"}
+
+// parseRFC3164 parses everything after "<PRI>": TIMESTAMP HOSTNAME TAG[PID]: MSG.
+// Senders often omit the timestamp or hostname, so every part is optional.
+func parseRFC3164(rest string, received time.Time, msg *message) {
+	if ts, remaining, ok := parseRFC3164Timestamp(rest, received); ok {
+		msg.timestamp = ts
+		rest = remaining
+
+		// The hostname is only present after a timestamp, and is not the tag itself
+		if host, remaining, ok := strings.Cut(rest, " "); ok && host != "" && !isTag(host) {
+			msg.hostname = host
+			rest = remaining
+		}
+	}
+
+	// TAG is alphanumeric and ends at '[' (PID follows) or ':'
+	end := strings.IndexAny(rest, "[: ")
+	if end > 0 && end <= 48 {
+		tag := rest[:end]
+		remaining := rest[end:]
+		if strings.HasPrefix(remaining, "[") {
+			if pidEnd := strings.Index(remaining, "]"); pidEnd > 0 {
+				msg.procID = remaining[1:pidEnd]
+				remaining = remaining[pidEnd+1:]
+			}
+		}
+		if strings.HasPrefix(remaining, ":") {
+			msg.appName = tag
+			rest = strings.TrimPrefix(remaining[1:], " ")
+		} else {
+			msg.procID = ""
+		}
+	}
+
+	msg.text = rest
+}
+
+// parseRFC3164Timestamp parses a leading "Mmm dd hh:mm:ss" timestamp, or an
+// RFC 3339 timestamp as sent by rsyslog with high-precision timestamps
+func parseRFC3164Timestamp(rest string, received time.Time) (time.Time, string, bool) {
+	if field, remaining, ok := strings.Cut(rest, " "); ok && len(field) > 0 && field[0] >= '0' && field[0] <= '9' {
+		if ts, err := time.Parse(time.RFC3339Nano, field); err == nil {
+			return ts, remaining, true
+		}
+	}
+
+	for _, layout := range rfc3164Layouts {
+		if len(rest) < len(layout) {
+			continue
+		}
+		ts, err := time.ParseInLocation(layout, rest[:len(layout)], received.Location())
+		if err != nil {
+			continue
+		}
+		if ts.Year() == 0 {
+			// No year in the timestamp: assume the current one, or the previous one
+			// for December messages received in January
+			ts = ts.AddDate(received.Year(), 0, 0)
+			if ts.After(received.Add(24 * time.Hour)) {
+				ts = ts.AddDate(-1, 0, 0)
+			}
+		}
+		return ts, strings.TrimPrefix(rest[len(layout):], " "), true
+	}
+
+	return time.Time{}, rest, false
+}
+
+// isTag reports whether a token is a TAG (e.g. "sshd[123]:" or "cron:") rather than a hostname
+func isTag(token string) bool {
+	return strings.HasSuffix(token, ":") || strings.Contains(token, "[")
+}
+
+func nilValue(field string) string {
+	if field == "-" {
+		return ""
+	}
+	return field
+}
+
+// toLogRecord converts a parsed message to an OTLP log record. peer is the
+// address of the sender, used as the host when the message has no hostname.
+func (msg message) toLogRecord(received time.Time, peer string) *logspb.LogRecord {
+	severity := syslogSeverities[msg.priority%8]
+	facility := msg.priority / 8
+
+	record := &logspb.LogRecord{
+		ObservedTimeUnixNano: uint64(received.UnixNano()),
+		SeverityNumber:       severity.number,
+		SeverityText:         severity.text,
+		Body:                 stringValue(msg.text),
+	}
+	if !msg.timestamp.IsZero() {
+		record.TimeUnixNano = uint64(msg.timestamp.UnixNano())
+	}
+
+	addAttr := func(key, value string) {
+		if value != "" {
+			record.Attributes = append(record.Attributes, &commonpb.KeyValue{Key: key, Value: stringValue(value)})
+		}
+	}
+
+	if facility < len(facilityNames) {
+		addAttr(AttrFacility, facilityNames[facility])
+	}
+	addAttr(AttrSeverity, severity.name)
+	if msg.hostname != "" {
+		addAttr(AttrHost, msg.hostname)
+	} else {
+		addAttr(AttrHost, peer)
+	}
+	addAttr(AttrAppName, msg.appName)
+	addAttr(AttrProcID, msg.procID)
+	addAttr(AttrMsgID, msg.msgID)
+	for _, element := range msg.structuredData {
+		for _, param := range element.params {
+			addAttr(attrStructuredDataPrefix+element.id+"."+param.name, param.value)
+		}
+	}
+
+	return record
+}
+
+func stringValue(s string) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: s}}
+}
@@ -0,0 +1,305 @@
+package syslogreceiver
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	maxMessageSize  = 64 * 1024 // Largest accepted message (UDP datagrams cannot be larger)
+	maxLengthDigits = 6         // Octet counts above maxMessageSize are rejected anyway
+)
+
+// Options configures the syslog listeners. A port of 0 disables the listener.
+type Options struct {
+	UDPPort     int
+	TCPPort     int
+	TLSCertFile string // Serve TCP over TLS when both the certificate and key are set
+	TLSKeyFile  string
+}
+
+// Receiver is a syslog receiver for RFC 5424 and RFC 3164 messages over UDP and TCP
+type Receiver struct {
+	opts        Options
+	udpConn     net.PacketConn
+	tcpListener net.Listener
+	lineChan    chan string
+	wg          sync.WaitGroup
+	ctx         context.Context
+	cancel      context.CancelFunc
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{} // Open TCP connections, closed on Stop
+
+	// JSON marshaler for passing records on as OTLP JSON lines
+	jsonMarshaler protojson.MarshalOptions
+}
+
+// NewReceiver creates a new syslog receiver
+func NewReceiver(opts Options) *Receiver {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Receiver{
+		opts:     opts,
+		lineChan: make(chan string, 1000),
+		ctx:      ctx,
+		cancel:   cancel,
+		conns:    make(map[net.Conn]struct{}),
+		jsonMarshaler: protojson.MarshalOptions{
+			EmitUnpopulated: false,
+		},
+	}
+}
+
+// Start starts the syslog listeners
+func (r *Receiver) Start() error {
+	var tlsConfig *tls.Config
+	if r.opts.TLSCertFile != "" || r.opts.TLSKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(r.opts.TLSCertFile, r.opts.TLSKeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load syslog TLS certificate: %w", err)
+		}
+		tlsConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+	}
+
+	// Start UDP listener
+	if r.opts.UDPPort > 0 {
+		udpConn, err := net.ListenPacket("udp", fmt.Sprintf(":%d", r.opts.UDPPort))
+		if err != nil {
+			return fmt.Errorf("failed to listen on syslog UDP port %d: %w", r.opts.UDPPort, err)
+		}
+		r.udpConn = udpConn
+
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			log.Printf("Syslog UDP receiver listening on port %d", r.opts.UDPPort)
+			r.serveUDP(udpConn)
+		}()
+	}
+
+	// Start TCP listener
+	if r.opts.TCPPort > 0 {
+		tcpListener, err := net.Listen("tcp", fmt.Sprintf(":%d", r.opts.TCPPort))
+		if err != nil {
+			r.closeListeners()
+			return fmt.Errorf("failed to listen on syslog TCP port %d: %w", r.opts.TCPPort, err)
+		}
+		if tlsConfig != nil {
+			tcpListener = tls.NewListener(tcpListener, tlsConfig)
+		}
+		r.tcpListener = tcpListener
+
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			if tlsConfig != nil {
+				log.Printf("Syslog TLS receiver listening on port %d", r.opts.TCPPort)
+			} else {
+				log.Printf("Syslog TCP receiver listening on port %d", r.opts.TCPPort)
+			}
+			r.serveTCP(tcpListener)
+		}()
+	}
+
+	return nil
+}
+
+// Stop stops the syslog receiver
+func (r *Receiver) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+
+	r.closeListeners()
+
+	r.mu.Lock()
+	for conn := range r.conns {
+		conn.Close()
+	}
+	r.mu.Unlock()
+
+	r.wg.Wait()
+	close(r.lineChan)
+}
+
+// GetLineChan returns the channel for receiving log lines
+func (r *Receiver) GetLineChan() <-chan string {
+	return r.lineChan
+}
+
+func (r *Receiver) closeListeners() {
+	if r.udpConn != nil {
+		r.udpConn.Close()
+	}
+	if r.tcpListener != nil {
+		r.tcpListener.Close()
+	}
+}
+
+// serveUDP handles datagrams, each of which holds one message
+func (r *Receiver) serveUDP(conn net.PacketConn) {
+	buf := make([]byte, maxMessageSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if r.ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+		r.handleMessage(string(buf[:n]), peerHost(addr))
+	}
+}
+
+// serveTCP accepts connections until the listener is closed
+func (r *Receiver) serveTCP(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if r.ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return
+			}
+			// Temporary accept errors (e.g. too many open files), back off briefly
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+
+		// Stop closes the tracked connections under r.mu after cancelling, so a
+		// connection accepted while stopping is closed here instead
+		r.mu.Lock()
+		if r.ctx.Err() != nil {
+			r.mu.Unlock()
+			conn.Close()
+			return
+		}
+		r.conns[conn] = struct{}{}
+		r.mu.Unlock()
+
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			defer func() {
+				r.mu.Lock()
+				delete(r.conns, conn)
+				r.mu.Unlock()
+				conn.Close()
+			}()
+			r.serveConn(conn)
+		}()
+	}
+}
+
+// serveConn reads the messages of a TCP connection. Every frame is either
+// octet-counted ("LEN MSG", RFC 6587) or terminated by a newline.
+func (r *Receiver) serveConn(conn net.Conn) {
+	peer := peerHost(conn.RemoteAddr())
+	reader := bufio.NewReaderSize(conn, maxMessageSize)
+
+	for {
+		frame, err := readFrame(reader)
+		if frame != "" {
+			r.handleMessage(frame, peer)
+		}
+		if err != nil {
+			// Silently drop the connection to avoid UI interference; EOF is the normal case
+			return
+		}
+	}
+}
+
+// readFrame reads the next message of a TCP stream
+func readFrame(reader *bufio.Reader) (string, error) {
+	first, err := reader.Peek(1)
+	if err != nil {
+		return "", err
+	}
+
+	if first[0] >= '1' && first[0] <= '9' {
+		// Octet counting: the frame starts with its length and a space
+		for i := 2; i <= maxLengthDigits+1; i++ {
+			header, err := reader.Peek(i)
+			if err != nil {
+				break
+			}
+			c := header[i-1]
+			if c == ' ' {
+				length, _ := strconv.Atoi(string(header[:i-1]))
+				if length > maxMessageSize {
+					return "", fmt.Errorf("syslog frame of %d bytes exceeds the limit", length)
+				}
+				reader.Discard(i)
+				frame := make([]byte, length)
+				if _, err := io.ReadFull(reader, frame); err != nil {
+					return "", err
+				}
+				return string(frame), nil
+			}
+			if c < '0' || c > '9' {
+				break // Not a length, fall back to newline framing
+			}
+		}
+	}
+
+	line, err := reader.ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) {
+		// Overlong line, pass on what fits and drop the rest of it
+		frame := string(line)
+		for errors.Is(err, bufio.ErrBufferFull) {
+			_, err = reader.ReadSlice('\n')
+		}
+		return frame, err
+	}
+	if errors.Is(err, io.EOF) && len(line) > 0 {
+		return string(line), nil
+	}
+	return string(line), err
+}
+
+// handleMessage parses a message and sends it on as an OTLP JSON record
+func (r *Receiver) handleMessage(frame, peer string) {
+	frame = strings.TrimRight(frame, "\r\n\x00")
+	if strings.TrimSpace(frame) == "" {
+		return
+	}
+
+	received := time.Now()
+	record := parseMessage(frame, received).toLogRecord(received, peer)
+
+	jsonBytes, err := r.jsonMarshaler.Marshal(record)
+	if err != nil {
+		log.Printf("Failed to convert syslog message to JSON: %v", err)
+		return
+	}
+
+	select {
+	case r.lineChan <- string(jsonBytes):
+	case <-r.ctx.Done():
+	}
+}
+
+// peerHost returns the IP address of a sender without the port
+func peerHost(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
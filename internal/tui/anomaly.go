@@ -0,0 +1,288 @@
+package tui
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultAnomalyThreshold is the z-score above which an interval is flagged as anomalous
+const DefaultAnomalyThreshold = 3.0
+
+const (
+	anomalyAlpha    = 0.1 // EWMA smoothing factor (higher adapts faster)
+	anomalyWarmup   = 10  // Intervals a series must be observed before it can be flagged
+	anomalyMinCount = 5   // Smallest count worth flagging, keeps sparse series quiet
+	maxAnomalies    = 50  // Anomalies kept for the stats modal
+)
+
+// anomalySeverities are the severity series checked for anomalies (FATAL includes CRITICAL)
+var anomalySeverities = []string{"FATAL", "ERROR", "WARN", "INFO", "DEBUG", "TRACE"}
+
+// Anomaly is a log rate spike compared to the learned baseline of a severity or service
+type Anomaly struct {
+	Start     time.Time // First interval of the spike
+	End       time.Time // Last interval of the spike
+	Intervals int       // Consecutive anomalous intervals merged into this anomaly
+	Severity  string
+	Service   string         // Empty for severity-wide anomalies
+	Count     int            // Highest interval count seen during the spike
+	Expected  float64        // Baseline count before the spike
+	ZScore    float64        // Highest z-score seen during the spike
+	Patterns  []PatternCount // Patterns that contributed the most logs
+}
+
+// ewmaBaseline tracks the exponentially weighted mean and variance of a count series
+type ewmaBaseline struct {
+	mean     float64
+	variance float64
+	samples  int
+}
+
+// observe scores value against the baseline and then folds it into the baseline
+func (b *ewmaBaseline) observe(value float64) (zScore float64, expected float64, ready bool) {
+	ready = b.samples >= anomalyWarmup
+	expected = b.mean
+
+	// Counts are roughly Poisson, so never trust a deviation below sqrt(mean)
+	stdDev := math.Max(math.Sqrt(b.variance), math.Max(math.Sqrt(b.mean), 1))
+	zScore = (value - b.mean) / stdDev
+
+	if b.samples == 0 {
+		b.mean = value
+	} else {
+		diff := value - b.mean
+		increment := anomalyAlpha * diff
+		b.mean += increment
+		b.variance = (1 - anomalyAlpha) * (b.variance + diff*increment)
+	}
+	b.samples++
+
+	return zScore, expected, ready
+}
+
+// isAnomalous reports whether a scored value should be flagged
+func isAnomalous(value int, zScore, threshold float64, ready bool) bool {
+	return ready && threshold > 0 && zScore >= threshold && value >= anomalyMinCount
+}
+
+// anomalyDetector keeps per-severity and per-service baselines of the interval log counts
+type anomalyDetector struct {
+	threshold float64
+	interval  time.Duration // Update interval the baselines were learned at
+
+	severities    map[string]*ewmaBaseline
+	services      map[string]*ewmaBaseline // Keyed by severity + "/" + service
+	serviceTotals map[string]int           // Cumulative service counts at the previous interval
+
+	// Pattern counts of the current interval, keyed by severity and by severity + "/" + service
+	patternCounts map[string]map[int64]int
+
+	anomalies []Anomaly
+	lastEval  time.Time
+}
+
+func newAnomalyDetector(threshold float64) *anomalyDetector {
+	d := &anomalyDetector{threshold: threshold}
+	d.reset()
+	return d
+}
+
+// reset drops all baselines and recorded anomalies
+func (d *anomalyDetector) reset() {
+	d.severities = make(map[string]*ewmaBaseline)
+	d.services = make(map[string]*ewmaBaseline)
+	d.serviceTotals = make(map[string]int)
+	d.patternCounts = make(map[string]map[int64]int)
+	d.anomalies = nil
+	d.lastEval = time.Time{}
+}
+
+// observeEntry records which pattern a log entry of the current interval belongs to
+func (d *anomalyDetector) observeEntry(entry LogEntry) {
+	if entry.PatternID == 0 {
+		return
+	}
+	severity := anomalySeverity(entry.Severity)
+	for _, key := range []string{severity, severity + "/" + GetServiceName(entry)} {
+		if d.patternCounts[key] == nil {
+			d.patternCounts[key] = make(map[int64]int)
+		}
+		d.patternCounts[key][entry.PatternID]++
+	}
+}
+
+// evaluate scores a finished interval and reports whether any series spiked.
+// Service counts are derived from the cumulative servicesBySeverity totals.
+func (d *anomalyDetector) evaluate(now time.Time, interval time.Duration, counts SeverityCounts, servicesBySeverity map[string][]ServiceCount, drain3 *Drain3Manager) bool {
+	// Counts are per interval, so a different interval makes the baselines meaningless
+	if interval != d.interval {
+		d.interval = interval
+		d.severities = make(map[string]*ewmaBaseline)
+		d.services = make(map[string]*ewmaBaseline)
+	}
+
+	previousEval := d.lastEval
+	d.lastEval = now
+	patternCounts := d.patternCounts
+	d.patternCounts = make(map[string]map[int64]int)
+
+	flagged := false
+	for _, severity := range anomalySeverities {
+		value := counts.Count(severity)
+		zScore, expected, ready := baselineFor(d.severities, severity).observe(float64(value))
+		if isAnomalous(value, zScore, d.threshold, ready) {
+			d.record(Anomaly{
+				Severity: severity,
+				Count:    value,
+				Expected: expected,
+				ZScore:   zScore,
+				Patterns: topPatterns(patternCounts[severity], drain3, 3),
+			}, now, previousEval)
+			flagged = true
+		}
+	}
+
+	// Per-service counts for this interval are the growth of the cumulative totals
+	totals := make(map[string]int)
+	for severity, services := range servicesBySeverity {
+		for _, service := range services {
+			if service.Service != "unknown" {
+				totals[anomalySeverity(severity)+"/"+service.Service] += service.Count
+			}
+		}
+	}
+	for key, total := range totals {
+		value := total - d.serviceTotals[key]
+		if value < 0 {
+			continue // Service dropped out of the top list and came back
+		}
+		zScore, expected, ready := baselineFor(d.services, key).observe(float64(value))
+		if isAnomalous(value, zScore, d.threshold, ready) {
+			severity, service, _ := strings.Cut(key, "/")
+			d.record(Anomaly{
+				Severity: severity,
+				Service:  service,
+				Count:    value,
+				Expected: expected,
+				ZScore:   zScore,
+				Patterns: topPatterns(patternCounts[key], drain3, 3),
+			}, now, previousEval)
+			flagged = true
+		}
+	}
+	d.serviceTotals = totals
+
+	return flagged
+}
+
+// record adds an anomaly, extending the previous one when the same series spiked in the last interval
+func (d *anomalyDetector) record(anomaly Anomaly, now, previousEval time.Time) {
+	for i := len(d.anomalies) - 1; i >= 0; i-- {
+		existing := &d.anomalies[i]
+		if existing.Severity != anomaly.Severity || existing.Service != anomaly.Service {
+			continue
+		}
+		if !previousEval.IsZero() && existing.End.Equal(previousEval) {
+			existing.End = now
+			existing.Intervals++
+			existing.ZScore = math.Max(existing.ZScore, anomaly.ZScore)
+			if anomaly.Count > existing.Count {
+				existing.Count = anomaly.Count
+				existing.Patterns = anomaly.Patterns
+			}
+			return
+		}
+		break
+	}
+
+	anomaly.Start = now
+	anomaly.End = now
+	anomaly.Intervals = 1
+	d.anomalies = append(d.anomalies, anomaly)
+	if len(d.anomalies) > maxAnomalies {
+		d.anomalies = d.anomalies[len(d.anomalies)-maxAnomalies:]
+	}
+}
+
+// recentAnomalies returns the recorded anomalies, newest first (severity-wide before per-service)
+func (d *anomalyDetector) recentAnomalies() []Anomaly {
+	anomalies := append([]Anomaly(nil), d.anomalies...)
+	sort.SliceStable(anomalies, func(i, j int) bool {
+		return anomalies[i].Start.After(anomalies[j].Start)
+	})
+	return anomalies
+}
+
+// heatmapAnomalies replays the heatmap counts of a severity, one bucket of step
+// at a time up to last, through a fresh baseline and returns the buckets that spiked
+func (m *DashboardModel) heatmapAnomalies(severity string, data []HeatmapMinute, step time.Duration, last time.Time) map[time.Time]bool {
+	flagged := make(map[time.Time]bool)
+	if m.anomalyDetector.threshold <= 0 || len(data) == 0 {
+		return flagged
+	}
+
+	counts := make(map[time.Time]int, len(data))
+	first := data[0].Timestamp
+	for _, bucket := range data {
+		counts[bucket.Timestamp] = bucket.Counts.Count(severity)
+		if bucket.Timestamp.Before(first) {
+			first = bucket.Timestamp
+		}
+	}
+
+	// Buckets without any logs are missing from the heatmap data and count as zero
+	baseline := &ewmaBaseline{}
+	for bucket := first; !bucket.After(last); bucket = bucket.Add(step) {
+		value := counts[bucket]
+		zScore, _, ready := baseline.observe(float64(value))
+		if isAnomalous(value, zScore, m.anomalyDetector.threshold, ready) {
+			flagged[bucket] = true
+		}
+	}
+	return flagged
+}
+
+func baselineFor(baselines map[string]*ewmaBaseline, key string) *ewmaBaseline {
+	baseline, ok := baselines[key]
+	if !ok {
+		baseline = &ewmaBaseline{}
+		baselines[key] = baseline
+	}
+	return baseline
+}
+
+// anomalySeverity maps a raw severity to the series it is counted in
+func anomalySeverity(severity string) string {
+	normalized := normalizeSeverityLevel(severity)
+	if normalized == "CRITICAL" {
+		return "FATAL"
+	}
+	return normalized
+}
+
+// topPatterns returns the patterns with the highest counts, resolved to their templates
+func topPatterns(counts map[int64]int, drain3 *Drain3Manager, limit int) []PatternCount {
+	ids := make([]int64, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if counts[ids[i]] != counts[ids[j]] {
+			return counts[ids[i]] > counts[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+
+	var patterns []PatternCount
+	for _, id := range ids {
+		if drain3 == nil || len(patterns) >= limit {
+			break
+		}
+		if info, _, ok := drain3.GetPattern(id); ok {
+			patterns = append(patterns, PatternCount{Pattern: info.Template, Count: counts[id]})
+		}
+	}
+	return patterns
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/control-theory/gonzo/internal/memory"
@@ -18,13 +19,16 @@ func (m *DashboardModel) calculateAttributesContentLines() int {
 	}
 
 	// Use lifetime attribute data instead of snapshot
-	lifetimeAttrs := m.getLifetimeAttributeEntries()
-	if len(lifetimeAttrs) == 0 {
+	itemCount := len(m.getLifetimeAttributeEntries())
+	if m.showNumericAttributes {
+		itemCount = len(m.getNumericAttributes())
+	}
+	if itemCount == 0 {
 		return minLines
 	}
 
 	// Use actual data count but never go below minimum
-	maxItems := min(len(lifetimeAttrs), 10)
+	maxItems := min(itemCount, 10)
 	if m.width < 80 {
 		maxItems = min(maxItems, 5)
 	}
@@ -40,6 +44,17 @@ func (m *DashboardModel) renderAttributesChart(width, height int) string {
 		style = activeSectionStyle.Width(width).Height(height)
 	}
 
+	// Numeric attributes replace the top attributes when toggled with 'a'
+	if m.showNumericAttributes {
+		numeric := m.getNumericAttributes()
+		title := chartTitleStyle.Render(fmt.Sprintf("Numeric Attributes (%d)", len(numeric)))
+		content := helpStyle.Render("No numeric attributes yet")
+		if len(numeric) > 0 {
+			content = m.renderNumericAttributesContent(width, numeric)
+		}
+		return style.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
+	}
+
 	title := chartTitleStyle.Render("Top Attributes")
 
 	var content string
@@ -130,3 +145,85 @@ func (m *DashboardModel) renderAttributesContent(chartWidth int, attributes []*m
 
 	return strings.Join(lines, "\n")
 }
+
+// renderNumericAttributesContent renders the percentiles of the numeric
+// attributes, with a sparkline of their recent p90 when there is room
+func (m *DashboardModel) renderNumericAttributesContent(chartWidth int, attributes []*NumericAttribute) string {
+	// One line goes to the column header
+	maxItems := 9
+	if m.width < 80 {
+		maxItems = 4
+	}
+	maxItems = min(maxItems, len(attributes))
+
+	// "%2d. " (4) + three values of " %7s" (24), the sparkline only if it fits
+	availableWidth := chartWidth - 2
+	sparkWidth := 12
+	labelWidth := availableWidth - 4 - 24 - (sparkWidth + 1)
+	if labelWidth < 12 {
+		sparkWidth = 0
+		labelWidth = max(availableWidth-4-24, 8)
+	}
+
+	var lines []string
+	grayStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	lines = append(lines, grayStyle.Render(fmt.Sprintf("    %-*s %7s %7s %7s", labelWidth, "", "p50", "p90", "p99")))
+	selectedIdx := m.selectedIndex[SectionAttributes]
+
+	for i := 0; i < maxItems; i++ {
+		attr := attributes[i]
+
+		key := attr.Key
+		if len(key) > labelWidth {
+			key = key[:labelWidth-3] + "..."
+		}
+
+		line := fmt.Sprintf("%2d. %-*s %7s %7s %7s", i+1, labelWidth, key,
+			memory.FormatNumber(attr.Values.Quantile(0.5)),
+			memory.FormatNumber(attr.Values.Quantile(0.9)),
+			memory.FormatNumber(attr.Values.Quantile(0.99)))
+
+		if i == selectedIdx && m.activeSection == SectionAttributes {
+			line = lipgloss.NewStyle().
+				Background(ColorBlue).
+				Foreground(ColorBlack).
+				Render(line)
+		} else {
+			line = lipgloss.NewStyle().
+				Foreground(ColorWhite).
+				Render(line)
+		}
+
+		if sparkWidth > 0 {
+			line += " " + lipgloss.NewStyle().Foreground(ColorGreen).Render(numericSparkline(attr, sparkWidth))
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// numericSparkline draws the p90 of an attribute for each of its last width minutes
+func numericSparkline(attr *NumericAttribute, width int) string {
+	buckets, _, _ := attr.buckets(numericFilter{}, width)
+
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, bucket := range buckets {
+		if bucket.Count > 0 {
+			low = min(low, bucket.Quantile(0.9))
+			high = max(high, bucket.Quantile(0.9))
+		}
+	}
+
+	var spark strings.Builder
+	spark.WriteString(strings.Repeat(" ", width-len(buckets)))
+	for _, bucket := range buckets {
+		if bucket.Count == 0 {
+			spark.WriteString(" ")
+			continue
+		}
+		spark.WriteRune(sparkRune(bucket.Quantile(0.9), low, high))
+	}
+	return spark.String()
+}
@@ -3,6 +3,7 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -82,6 +83,8 @@ func (m *DashboardModel) renderStatusLine() string {
 		} else {
 			statusText = "Type search term • Enter: Apply • ESC: Cancel"
 		}
+	} else if m.statusNotice != "" && time.Since(m.statusNoticeTime) < statusNoticeDuration {
+		statusText = m.statusNotice
 	} else if m.activeSection == SectionLogs {
 		if veryNarrow {
 			statusText = "?: Help • ↑↓ Nav • Enter"
@@ -133,6 +136,21 @@ func (m *DashboardModel) renderStatusLine() string {
 		}
 	}
 
+	// Replay speed and position, e.g. "▶ 10x 14:03:22"
+	if m.replayStatus != nil && !m.filterActive && !m.searchActive && !veryNarrow {
+		replayInfo := "▶ " + m.replayStatus.Speed
+		if m.replayStatus.Paused {
+			replayInfo = "⏸ replay"
+		}
+		if !m.replayStatus.Position.IsZero() {
+			replayInfo += " " + m.replayStatus.Position.Format("15:04:05")
+		}
+		if statusInfo != "" {
+			replayInfo += " • " + statusInfo
+		}
+		statusInfo = replayInfo
+	}
+
 	// Add branding (show unless terminal is very narrow)
 	branding := ""
 	if m.width >= 30 { // Show branding unless terminal is very narrow
@@ -218,7 +236,7 @@ func (m *DashboardModel) renderFilter() string {
 		title = "🔍 Filter (editing)"
 		content = m.filterInput.View()
 		styleColor = ColorGreen
-		if m.filterRegex != nil {
+		if m.hasFilter() {
 			content += fmt.Sprintf(" | Showing: %d/%d entries", len(m.logEntries), len(m.allLogEntries))
 		}
 	} else if m.searchActive {
@@ -229,7 +247,7 @@ func (m *DashboardModel) renderFilter() string {
 		if m.searchTerm != "" {
 			content += fmt.Sprintf(" | Highlighting: %q", m.searchTerm)
 		}
-	} else if m.filterRegex != nil || m.filterInput.Value() != "" {
+	} else if m.hasFilter() || m.filterInput.Value() != "" {
 		// Filter applied but not editing - show the filter value
 		title = "🔍 Filter"
 		content = fmt.Sprintf("[%s]", m.filterInput.Value())
@@ -438,14 +456,18 @@ func (m *DashboardModel) buildFilterStatus() []string {
 		}
 	}
 
-	// Check regex filter
-	if m.filterRegex != nil {
+	// Check regex/query filter
+	if m.hasFilter() {
 		pattern := m.filterInput.Value()
 		if pattern == "" && m.filterRegex != nil {
 			pattern = m.filterRegex.String()
 		}
 		if pattern != "" {
-			filters = append(filters, "  • Regex filter: "+pattern)
+			if m.filterQuery != nil {
+				filters = append(filters, "  • Query filter: "+pattern)
+			} else {
+				filters = append(filters, "  • Regex filter: "+pattern)
+			}
 		}
 	}
 
@@ -461,8 +483,8 @@ func (m *DashboardModel) buildFilterStatus() []string {
 		if m.severityFilterActive {
 			filters = append(filters, "    • Ctrl+F → Select All → Enter (enable all severities)")
 		}
-		if m.filterRegex != nil {
-			filters = append(filters, "    • / → Backspace/Delete → Enter (clear regex)")
+		if m.hasFilter() {
+			filters = append(filters, "    • / → Backspace/Delete → Enter (clear filter)")
 		}
 		if m.searchTerm != "" {
 			filters = append(filters, "    • s → Backspace/Delete → Enter (clear search)")
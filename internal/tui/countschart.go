@@ -3,6 +3,7 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/NimbleMarkets/ntcharts/barchart"
 	"github.com/charmbracelet/lipgloss"
@@ -30,14 +31,23 @@ func (m *DashboardModel) renderCountsChart(width, height int) string {
 		style = activeSectionStyle.Width(width).Height(height)
 	}
 
+	// Bars per update interval, or per event-time bucket
+	history := m.countsHistory
+	leftTitle := "Log Counts"
+	if m.eventTimeAxis {
+		var step time.Duration
+		history, step = m.eventCounts(countsChartBars(width))
+		leftTitle = fmt.Sprintf("Log Counts (%s bars)", formatStep(step))
+	}
+
 	// Create header with title on left and min/max/latest stats on right
 	var headerText string
-	if len(m.countsHistory) > 0 {
-		latest := m.countsHistory[len(m.countsHistory)-1]
+	if len(history) > 0 {
+		latest := history[len(history)-1]
 
 		// Calculate min/max totals across history
 		minTotal, maxTotal := latest.Total, latest.Total
-		for _, counts := range m.countsHistory {
+		for _, counts := range history {
 			if counts.Total < minTotal {
 				minTotal = counts.Total
 			}
@@ -46,8 +56,7 @@ func (m *DashboardModel) renderCountsChart(width, height int) string {
 			}
 		}
 
-		// Create left and right parts of header
-		leftTitle := "Log Counts"
+		// Create right part of header
 		rightStats := fmt.Sprintf("Min: %d | Max: %d", minTotal, maxTotal)
 
 		// Calculate available space (account for borders and padding)
@@ -63,14 +72,14 @@ func (m *DashboardModel) renderCountsChart(width, height int) string {
 			headerText = leftTitle
 		}
 	} else {
-		headerText = "Log Counts"
+		headerText = leftTitle
 	}
 
 	title := chartTitleStyle.Render(headerText)
 
 	var content string
-	if len(m.countsHistory) > 0 {
-		content = m.renderCountsContent(width)
+	if len(history) > 0 {
+		content = m.renderCountsContent(width, history)
 	} else {
 		content = helpStyle.Render("No data available")
 	}
@@ -78,16 +87,27 @@ func (m *DashboardModel) renderCountsChart(width, height int) string {
 	return style.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
 }
 
-// renderCountsContent renders a stacked bar chart for log counts by severity
-func (m *DashboardModel) renderCountsContent(chartWidth int) string {
-	if len(m.countsHistory) == 0 {
-		return helpStyle.Render("No data available")
+// countsChartBars returns how many bars fit in a counts chart of the given width
+func countsChartBars(chartWidth int) int {
+	// Reserve space for the legend (18) and separator (2), as renderCountsContent does
+	return max(chartWidth-18-2, 20) / 3
+}
+
+// eventCounts returns the counts chart bars by original log time: the buckets
+// of the event-time heatmap, as many as fit in the chart
+func (m *DashboardModel) eventCounts(maxBars int) ([]SeverityCounts, time.Duration) {
+	buckets, step := m.eventBuckets(maxBars)
+	counts := make([]SeverityCounts, len(buckets))
+	for i, bucket := range buckets {
+		counts[i] = bucket.Counts
 	}
+	return counts, step
+}
 
-	// Calculate total logs for debugging if needed
-	totalLogs := 0
-	for _, counts := range m.countsHistory {
-		totalLogs += counts.Total
+// renderCountsContent renders a stacked bar chart for log counts by severity
+func (m *DashboardModel) renderCountsContent(chartWidth int, history []SeverityCounts) string {
+	if len(history) == 0 {
+		return helpStyle.Render("No data available")
 	}
 
 	// Reserve space for legend on the right side
@@ -105,7 +125,7 @@ func (m *DashboardModel) renderCountsContent(chartWidth int) string {
 	}
 
 	// Prepare data for stacked bar chart
-	dataPoints := len(m.countsHistory)
+	dataPoints := len(history)
 	maxBars := actualChartWidth / 3 // Conservative spacing
 
 	// Always show the most recent data points that fit in the available space
@@ -122,8 +142,22 @@ func (m *DashboardModel) renderCountsContent(chartWidth int) string {
 		dataStartIdx = dataPoints - maxBars
 	}
 
+	// Anomalous intervals get a marker row above the bars
+	anomalous := make([]bool, paddingCount, maxBars)
+	hasAnomalies := false
+	for i := 0; i < min(dataPoints, maxBars-paddingCount); i++ {
+		// Anomalies are flagged per update interval, not per event-time bucket
+		flagged := !m.eventTimeAxis && m.isCountsIntervalAnomalous(dataStartIdx+i)
+		anomalous = append(anomalous, flagged)
+		hasAnomalies = hasAnomalies || flagged
+	}
+	barsHeight := chartHeight
+	if hasAnomalies {
+		barsHeight--
+	}
+
 	// Create ntcharts bar chart with stacked bars
-	bc := barchart.New(actualChartWidth, chartHeight,
+	bc := barchart.New(actualChartWidth, barsHeight,
 		barchart.WithBarGap(1),   // Gap of 1 for better visibility
 		barchart.WithBarWidth(1), // Conservative width for readability
 		barchart.WithNoAxis(),    // Remove axis lines
@@ -159,7 +193,7 @@ func (m *DashboardModel) renderCountsContent(chartWidth int) string {
 	// Add actual data (most recent points on the right) - stacked bar approach
 	actualDataCount := min(dataPoints, maxBars-paddingCount)
 	for i := 0; i < actualDataCount; i++ {
-		counts := m.countsHistory[dataStartIdx+i]
+		counts := history[dataStartIdx+i]
 
 		// Create stacked bars with multiple severity levels
 		var barValues []barchart.BarValue
@@ -208,11 +242,14 @@ func (m *DashboardModel) renderCountsContent(chartWidth int) string {
 
 	bc.Draw()
 	chartOutput := bc.View()
+	if hasAnomalies {
+		chartOutput = renderAnomalyMarkers(anomalous, bc.BarWidth(), bc.BarGap(), actualChartWidth) + "\n" + chartOutput
+	}
 
 	// Create vertical legend on the right side
 	var legend string
-	if len(m.countsHistory) > 0 {
-		latest := m.countsHistory[len(m.countsHistory)-1]
+	if len(history) > 0 {
+		latest := history[len(history)-1]
 
 		// Define severity levels in priority order with full names
 		// Match the stacking order: TRACE → DEBUG → INFO → WARN → ERROR → FATAL (bottom to top)
@@ -302,3 +339,37 @@ func (m *DashboardModel) renderCountsContent(chartWidth int) string {
 
 	return strings.Join(combinedLines, "\n")
 }
+
+// isCountsIntervalAnomalous reports whether the countsHistory interval at index was flagged.
+// The flags are aligned from the end because a restored session has no flags for older intervals.
+func (m *DashboardModel) isCountsIntervalAnomalous(index int) bool {
+	flagIndex := index - (len(m.countsHistory) - len(m.countsAnomalous))
+	return flagIndex >= 0 && flagIndex < len(m.countsAnomalous) && m.countsAnomalous[flagIndex]
+}
+
+// renderAnomalyMarkers renders a row with a marker above each anomalous bar
+func renderAnomalyMarkers(anomalous []bool, barWidth, barGap, width int) string {
+	markerStyle := lipgloss.NewStyle().Foreground(ColorRed).Bold(true)
+
+	var line strings.Builder
+	column := 0
+	for _, flagged := range anomalous {
+		if column+barWidth > width {
+			break
+		}
+		if flagged {
+			line.WriteString(markerStyle.Render(strings.Repeat("▼", barWidth)))
+		} else {
+			line.WriteString(strings.Repeat(" ", barWidth))
+		}
+		column += barWidth
+		if gap := min(barGap, width-column); gap > 0 {
+			line.WriteString(strings.Repeat(" ", gap))
+			column += gap
+		}
+	}
+	if column < width {
+		line.WriteString(strings.Repeat(" ", width-column))
+	}
+	return line.String()
+}
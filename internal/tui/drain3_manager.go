@@ -1,7 +1,10 @@
 package tui
 
 import (
+	"encoding/json"
+	"errors"
 	"github.com/control-theory/gonzo/internal/drain3"
+	"os"
 	"sort"
 	"strings"
 	"time"
@@ -11,48 +14,162 @@ import (
 
 // Drain3Manager manages the drain3 instance for pattern extraction
 type Drain3Manager struct {
-	drain       *drain3.Drain
-	lastReset   time.Time
-	totalCount  int
+	drain      *drain3.Drain
+	lastReset  time.Time
+	totalCount int
+	idOffset   int64 // Added to cluster IDs so pattern IDs stay unique across resets
+
+	// Persistent templates (see LoadPatternsState)
+	keepTemplates  bool // Reset only clears counts so learned templates stay stable
+	baselineLoaded bool // Templates from a previous run were loaded
+	baselineSize   int  // Number of templates loaded from a previous run
+
+	// New pattern detection
+	startTime      time.Time
+	baselinePeriod time.Duration       // Patterns first seen within this period after start form the baseline
+	newPatterns    map[int64]time.Time // First-seen time of patterns flagged as new
+}
+
+// DefaultNewPatternBaseline is how long patterns are learned before new ones are flagged
+const DefaultNewPatternBaseline = 2 * time.Minute
+
+// NewPatternInfo describes a pattern that appeared after the baseline
+type NewPatternInfo struct {
+	ID        int64
+	Template  string
+	Count     int
+	FirstSeen time.Time
 }
 
 // PatternInfo represents a log pattern with its statistics
 type PatternInfo struct {
+	ID         int64 // Pattern ID, as stored in LogEntry.PatternID
 	Template   string
 	Count      int
 	Percentage float64
+	IsNew      bool // First seen after the baseline (see IsNewPattern)
 }
 
 // NewDrain3Manager creates a new drain3 manager with optimized settings for log pattern extraction
 func NewDrain3Manager() *Drain3Manager {
 	// Use optimized config for real-time log processing
 	config := &drain3.Config{
-		Depth:        4,    // Moderate depth for balanced pattern extraction
-		SimilarityTh: 0.5,  // 50% similarity threshold - balanced clustering
-		MaxChildren:  50,   // Lower for performance in real-time
-		MaxClusters:  100,  // Keep top 100 patterns
+		Depth:        4,   // Moderate depth for balanced pattern extraction
+		SimilarityTh: 0.5, // 50% similarity threshold - balanced clustering
+		MaxChildren:  50,  // Lower for performance in real-time
+		MaxClusters:  100, // Keep top 100 patterns
 	}
 
 	return &Drain3Manager{
-		drain:     drain3.New(config),
-		lastReset: time.Now(),
+		drain:          drain3.New(config),
+		lastReset:      time.Now(),
+		startTime:      time.Now(),
+		baselinePeriod: DefaultNewPatternBaseline,
+		newPatterns:    make(map[int64]time.Time),
 	}
 }
 
-// AddLogMessage processes a log message and extracts its pattern
-func (dm *Drain3Manager) AddLogMessage(message string) {
+// AddLogMessage processes a log message and returns the ID of the pattern it
+// belongs to (0 if the message was not clustered)
+func (dm *Drain3Manager) AddLogMessage(message string) int64 {
 	if dm.drain == nil {
-		return
+		return 0
 	}
 
 	// Skip empty messages
 	if strings.TrimSpace(message) == "" {
-		return
+		return 0
 	}
 
 	// Add to drain3 for pattern extraction
-	_ = dm.drain.AddLogMessage(message)
+	cluster, created, err := dm.drain.AddLogMessage(message)
 	dm.totalCount++
+	if err != nil || cluster == nil {
+		return 0
+	}
+
+	id := dm.idOffset + cluster.ClusterId
+	if created && (dm.baselineLoaded || time.Since(dm.startTime) >= dm.baselinePeriod) {
+		dm.newPatterns[id] = time.Now()
+	}
+	return id
+}
+
+// SetNewPatternBaseline sets how long after start patterns are treated as the
+// baseline rather than flagged as new
+func (dm *Drain3Manager) SetNewPatternBaseline(period time.Duration) {
+	dm.baselinePeriod = period
+}
+
+// GetNewPatterns returns the patterns flagged as new, most recently seen first
+func (dm *Drain3Manager) GetNewPatterns() []NewPatternInfo {
+	if dm.drain == nil || len(dm.newPatterns) == 0 {
+		return nil
+	}
+
+	var patterns []NewPatternInfo
+	for _, cluster := range dm.drain.GetClusters() {
+		id := dm.idOffset + cluster.ClusterId
+		firstSeen, ok := dm.newPatterns[id]
+		if !ok || cluster.Size == 0 {
+			continue
+		}
+		patterns = append(patterns, NewPatternInfo{
+			ID:        id,
+			Template:  formatTemplate(cluster),
+			Count:     int(cluster.Size),
+			FirstSeen: firstSeen,
+		})
+	}
+
+	sort.Slice(patterns, func(i, j int) bool {
+		if patterns[i].FirstSeen.Equal(patterns[j].FirstSeen) {
+			return patterns[i].ID > patterns[j].ID
+		}
+		return patterns[i].FirstSeen.After(patterns[j].FirstSeen)
+	})
+	return patterns
+}
+
+// GetPattern returns the pattern with the given ID and its raw template tokens
+func (dm *Drain3Manager) GetPattern(id int64) (PatternInfo, []string, bool) {
+	if dm.drain == nil {
+		return PatternInfo{}, nil, false
+	}
+
+	for _, cluster := range dm.drain.GetClusters() {
+		if dm.idOffset+cluster.ClusterId == id {
+			info := PatternInfo{
+				ID:       id,
+				Template: formatTemplate(cluster),
+				Count:    int(cluster.Size),
+				IsNew:    dm.IsNewPattern(id),
+			}
+			if dm.totalCount > 0 {
+				info.Percentage = float64(info.Count) * 100.0 / float64(dm.totalCount)
+			}
+			return info, cluster.LogTemplateTokens, true
+		}
+	}
+	return PatternInfo{}, nil, false
+}
+
+// extractPatternParameters returns the message tokens that fill the <*> slots of
+// a template, or nil if the message does not line up with the template
+func extractPatternParameters(templateTokens []string, message string) []string {
+	// Tokenize the same way drain3 does
+	tokens := strings.Split(strings.TrimSpace(message), " ")
+	if len(tokens) != len(templateTokens) {
+		return nil
+	}
+
+	var params []string
+	for i, token := range templateTokens {
+		if token == "<*>" {
+			params = append(params, tokens[i])
+		}
+	}
+	return params
 }
 
 // GetTopPatterns returns the top N patterns by frequency
@@ -69,12 +186,19 @@ func (dm *Drain3Manager) GetTopPatterns(limit int) []PatternInfo {
 	// Convert to PatternInfo and sort by count
 	patterns := make([]PatternInfo, 0, len(clusters))
 	for _, cluster := range clusters {
+		// Templates restored from a previous run are not shown until they are seen again
+		if cluster.Size == 0 {
+			continue
+		}
 		template := formatTemplate(cluster)
 		if template != "" {
+			id := dm.idOffset + cluster.ClusterId
 			patterns = append(patterns, PatternInfo{
+				ID:         id,
 				Template:   template,
 				Count:      int(cluster.Size),
 				Percentage: 0, // Will calculate after sorting
+				IsNew:      dm.IsNewPattern(id),
 			})
 		}
 	}
@@ -114,10 +238,10 @@ func formatTemplate(cluster *goDrain.LogCluster) string {
 
 	// Join tokens and replace placeholders with more readable format
 	template := strings.Join(cluster.LogTemplateTokens, " ")
-	
+
 	// Replace drain3 placeholders (<*>) with more readable ones
 	template = strings.ReplaceAll(template, "<*>", "***")
-	
+
 	// Truncate very long templates
 	if len(template) > 100 {
 		template = template[:97] + "..."
@@ -126,12 +250,26 @@ func formatTemplate(cluster *goDrain.LogCluster) string {
 	return template
 }
 
-// Reset clears the drain3 instance and starts fresh
+// Reset clears the drain3 instance and starts fresh. With persistent templates
+// only the counts are cleared.
 func (dm *Drain3Manager) Reset() {
+	if dm.drain != nil && dm.keepTemplates {
+		// Known templates survive, so patterns flagged as new stay flagged
+		dm.drain.ResetCounts()
+		dm.lastReset = time.Now()
+		dm.totalCount = 0
+		return
+	}
 	if dm.drain != nil {
+		// Continue numbering after the discarded clusters so old pattern IDs are never reused
+		dm.idOffset += dm.drain.ClustersCounter
 		_ = dm.drain.Reset()
 		dm.lastReset = time.Now()
 		dm.totalCount = 0
+
+		// Everything is relearned, so start a new baseline period
+		dm.startTime = time.Now()
+		dm.newPatterns = make(map[int64]time.Time)
 	}
 }
 
@@ -141,11 +279,122 @@ func (dm *Drain3Manager) GetStats() (patternCount int, totalLogs int) {
 		return 0, 0
 	}
 
-	clusters := dm.drain.GetClusters()
-	return len(clusters), dm.totalCount
+	for _, cluster := range dm.drain.GetClusters() {
+		if cluster.Size > 0 {
+			patternCount++
+		}
+	}
+	return patternCount, dm.totalCount
+}
+
+// IsNewPattern reports whether a pattern first appeared after the baseline
+// period, or was absent from the templates loaded from a previous run
+func (dm *Drain3Manager) IsNewPattern(id int64) bool {
+	_, ok := dm.newPatterns[id]
+	return ok
+}
+
+// LoadPatternsState restores templates saved by SavePatternsState and keeps them
+// across resets. The restored templates form the baseline used to flag new
+// patterns; their counts start from zero. A missing file is not an error.
+func (dm *Drain3Manager) LoadPatternsState(path string) error {
+	if dm.drain == nil {
+		return nil
+	}
+	dm.keepTemplates = true
+
+	if err := dm.drain.LoadFile(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	dm.drain.ResetCounts()
+	dm.totalCount = 0
+	dm.idOffset = 0
+	dm.baselineLoaded = true
+	dm.baselineSize = len(dm.drain.GetClusters())
+	dm.newPatterns = make(map[int64]time.Time)
+	return nil
+}
+
+// SavePatternsState writes the learned templates to path
+func (dm *Drain3Manager) SavePatternsState(path string) error {
+	if dm.drain == nil {
+		return nil
+	}
+	return dm.drain.SaveFile(path)
+}
+
+// BaselineSize returns the number of patterns loaded from a previous run
+func (dm *Drain3Manager) BaselineSize() int {
+	return dm.baselineSize
+}
+
+// drain3ManagerState is the serialized form of a Drain3Manager
+type drain3ManagerState struct {
+	Drain          json.RawMessage     `json:"drain"`
+	TotalCount     int                 `json:"total_count"`
+	IDOffset       int64               `json:"id_offset,omitempty"`
+	KeepTemplates  bool                `json:"keep_templates,omitempty"`
+	BaselineLoaded bool                `json:"baseline_loaded,omitempty"`
+	BaselineSize   int                 `json:"baseline_size,omitempty"`
+	StartTime      time.Time           `json:"start_time"`
+	NewPatterns    map[int64]time.Time `json:"new_patterns,omitempty"`
+}
+
+// MarshalJSON serializes the pattern tree and counters for session persistence
+func (dm *Drain3Manager) MarshalJSON() ([]byte, error) {
+	state := drain3ManagerState{
+		TotalCount:     dm.totalCount,
+		IDOffset:       dm.idOffset,
+		KeepTemplates:  dm.keepTemplates,
+		BaselineLoaded: dm.baselineLoaded,
+		BaselineSize:   dm.baselineSize,
+		StartTime:      dm.startTime,
+		NewPatterns:    dm.newPatterns,
+	}
+	if dm.drain != nil {
+		data, err := dm.drain.MarshalState()
+		if err != nil {
+			return nil, err
+		}
+		state.Drain = data
+	}
+	return json.Marshal(state)
+}
+
+// UnmarshalJSON restores a manager previously serialized with MarshalJSON
+func (dm *Drain3Manager) UnmarshalJSON(data []byte) error {
+	var state drain3ManagerState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+
+	restored := NewDrain3Manager()
+	if len(state.Drain) > 0 && restored.drain != nil {
+		if err := restored.drain.LoadState(state.Drain); err != nil {
+			return err
+		}
+	}
+	restored.totalCount = state.TotalCount
+	restored.idOffset = state.IDOffset
+	restored.keepTemplates = state.KeepTemplates
+	restored.baselineLoaded = state.BaselineLoaded
+	restored.baselineSize = state.BaselineSize
+	if !state.StartTime.IsZero() {
+		restored.startTime = state.StartTime
+	}
+	if state.NewPatterns != nil {
+		restored.newPatterns = state.NewPatterns
+	}
+
+	*dm = *restored
+	return nil
 }
 
 // ShouldReset checks if it's time to reset based on a duration
 func (dm *Drain3Manager) ShouldReset(resetInterval time.Duration) bool {
 	return time.Since(dm.lastReset) >= resetInterval
-}
\ No newline at end of file
+}
@@ -0,0 +1,279 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// eventTimeMaxBuckets limits how many buckets the event-time heatmap keeps.
+// Once the event-time range needs more, the buckets are merged into the next
+// coarser step, so that no time range is ever dropped.
+const eventTimeMaxBuckets = 7 * 24 * 60
+
+// eventTimeSteps are the bucket sizes event-time charts choose from, so that
+// the whole time range fits the available columns
+var eventTimeSteps = []time.Duration{
+	time.Minute, 2 * time.Minute, 5 * time.Minute, 10 * time.Minute, 15 * time.Minute, 30 * time.Minute,
+	time.Hour, 2 * time.Hour, 3 * time.Hour, 6 * time.Hour, 12 * time.Hour, 24 * time.Hour,
+	48 * time.Hour, 7 * 24 * time.Hour, 14 * 24 * time.Hour, 28 * 24 * time.Hour,
+}
+
+// SetEventTimeAxis makes the counts chart, heatmap and rate statistics use the
+// original log timestamps instead of the receive time
+func (m *DashboardModel) SetEventTimeAxis(enabled bool) {
+	m.eventTimeAxis = enabled
+}
+
+// eventTimeOf returns the time an entry is bucketed at on the event-time axis.
+// Entries without an original timestamp (e.g. continuation lines) belong to the
+// time of the entry before them.
+func (m *DashboardModel) eventTimeOf(entry LogEntry) time.Time {
+	if !entry.OrigTimestamp.IsZero() {
+		m.lastEventTime = entry.OrigTimestamp
+		return entry.OrigTimestamp
+	}
+	if !m.lastEventTime.IsZero() {
+		return m.lastEventTime
+	}
+	return entry.Timestamp
+}
+
+// updateEventHeatmap counts an entry in the bucket of its original timestamp.
+// The buckets are kept sorted, so out-of-order entries land where they belong.
+func (m *DashboardModel) updateEventHeatmap(entry LogEntry) {
+	step := m.eventHeatmapStepOrMinute()
+	bucket := m.eventTimeOf(entry).Truncate(step)
+
+	i := sort.Search(len(m.eventHeatmapData), func(i int) bool {
+		return !m.eventHeatmapData[i].Timestamp.Before(bucket)
+	})
+	if i == len(m.eventHeatmapData) || !m.eventHeatmapData[i].Timestamp.Equal(bucket) {
+		m.eventHeatmapData = append(m.eventHeatmapData, HeatmapMinute{})
+		copy(m.eventHeatmapData[i+1:], m.eventHeatmapData[i:])
+		m.eventHeatmapData[i] = HeatmapMinute{Timestamp: bucket}
+	}
+	m.eventHeatmapData[i].Counts.AddCount(entry.Severity)
+
+	// Merge into coarser buckets while the range needs too many of them
+	for m.eventHeatmapSpan() > eventTimeMaxBuckets {
+		coarser := nextEventTimeStep(m.eventHeatmapStepOrMinute())
+		if coarser == 0 {
+			break
+		}
+		m.coarsenEventHeatmap(coarser)
+	}
+}
+
+// eventHeatmapStepOrMinute returns the bucket size of the event-time heatmap
+func (m *DashboardModel) eventHeatmapStepOrMinute() time.Duration {
+	if m.eventHeatmapStep <= 0 {
+		return time.Minute
+	}
+	return m.eventHeatmapStep
+}
+
+// eventHeatmapSpan returns the number of buckets from the oldest to the newest
+func (m *DashboardModel) eventHeatmapSpan() int {
+	n := len(m.eventHeatmapData)
+	if n == 0 {
+		return 0
+	}
+	return int(m.eventHeatmapData[n-1].Timestamp.Sub(m.eventHeatmapData[0].Timestamp)/m.eventHeatmapStepOrMinute()) + 1
+}
+
+// nextEventTimeStep returns the smallest step that is a multiple of step, so
+// that buckets merge without splitting, or 0 if there is none
+func nextEventTimeStep(step time.Duration) time.Duration {
+	for _, candidate := range eventTimeSteps {
+		if candidate > step && candidate%step == 0 {
+			return candidate
+		}
+	}
+	return 0
+}
+
+// coarsenEventHeatmap merges the event-time heatmap into buckets of step
+func (m *DashboardModel) coarsenEventHeatmap(step time.Duration) {
+	merged := m.eventHeatmapData[:0]
+	for _, bucket := range m.eventHeatmapData {
+		timestamp := bucket.Timestamp.Truncate(step)
+		if n := len(merged); n > 0 && merged[n-1].Timestamp.Equal(timestamp) {
+			addSeverityCounts(&merged[n-1].Counts, bucket.Counts)
+			continue
+		}
+		bucket.Timestamp = timestamp
+		merged = append(merged, bucket)
+	}
+	m.eventHeatmapData = merged
+	m.eventHeatmapStep = step
+}
+
+// eventBuckets aggregates the event-time heatmap into at most n buckets of the
+// smallest step that covers its whole range. The buckets end with the newest
+// entry; empty buckets are included.
+func (m *DashboardModel) eventBuckets(n int) ([]HeatmapMinute, time.Duration) {
+	if len(m.eventHeatmapData) == 0 || n <= 0 {
+		return nil, time.Minute
+	}
+	first := m.eventHeatmapData[0].Timestamp
+	last := m.eventHeatmapData[len(m.eventHeatmapData)-1].Timestamp
+
+	// Steps finer than the kept buckets, or splitting them, cannot be shown
+	stored := m.eventHeatmapStepOrMinute()
+	step := eventTimeSteps[len(eventTimeSteps)-1]
+	for _, candidate := range eventTimeSteps {
+		if candidate < stored || candidate%stored != 0 {
+			continue
+		}
+		if int(last.Truncate(candidate).Sub(first.Truncate(candidate))/candidate) < n {
+			step = candidate
+			break
+		}
+	}
+
+	end := last.Truncate(step)
+	start := end.Add(-time.Duration(n-1) * step)
+	if firstBucket := first.Truncate(step); firstBucket.After(start) {
+		start = firstBucket
+	}
+
+	buckets := make([]HeatmapMinute, int(end.Sub(start)/step)+1)
+	for i := range buckets {
+		buckets[i].Timestamp = start.Add(time.Duration(i) * step)
+	}
+	for _, minute := range m.eventHeatmapData {
+		i := int(minute.Timestamp.Truncate(step).Sub(start) / step)
+		if i < 0 || i >= len(buckets) {
+			continue
+		}
+		addSeverityCounts(&buckets[i].Counts, minute.Counts)
+	}
+	return buckets, step
+}
+
+// addSeverityCounts adds the counts of b to a
+func addSeverityCounts(a *SeverityCounts, b SeverityCounts) {
+	a.Trace += b.Trace
+	a.Debug += b.Debug
+	a.Info += b.Info
+	a.Warn += b.Warn
+	a.Error += b.Error
+	a.Fatal += b.Fatal
+	a.Critical += b.Critical
+	a.Unknown += b.Unknown
+	a.Total += b.Total
+}
+
+// eventTimeStats returns the event-time range and rates for the statistics modal
+func (m *DashboardModel) eventTimeStats() []StatItem {
+	if len(m.eventHeatmapData) == 0 {
+		return nil
+	}
+	step := m.eventHeatmapStepOrMinute()
+	first := m.eventHeatmapData[0].Timestamp
+	last := m.eventHeatmapData[len(m.eventHeatmapData)-1].Timestamp.Add(step)
+
+	total, peak := 0, 0
+	for _, minute := range m.eventHeatmapData {
+		total += minute.Counts.Total
+		peak = max(peak, minute.Counts.Total)
+	}
+	span := last.Sub(first)
+
+	endLayout := "15:04"
+	if last.Sub(first) >= 24*time.Hour || last.Day() != first.Day() {
+		endLayout = "2006-01-02 15:04"
+	}
+	items := []StatItem{
+		{"Event Time Range", fmt.Sprintf("%s - %s", first.Format("2006-01-02 15:04"), last.Format(endLayout))},
+		{"Average Event Rate", fmt.Sprintf("%.1f logs/min", float64(total)/span.Minutes())},
+	}
+	if step == time.Minute {
+		items = append(items, StatItem{"Peak Event Rate", fmt.Sprintf("%d logs/min", peak)})
+	} else {
+		items = append(items, StatItem{"Peak Event Rate", fmt.Sprintf("%d logs/%s", peak, formatStep(step))})
+	}
+	return items
+}
+
+// eventHeatmapColumns is the number of buckets of the event-time heatmap,
+// matching the 61 minutes of the receive-time heatmap
+const eventHeatmapColumns = 61
+
+// renderEventHeatmapSection renders the severity heatmap over the original log
+// time. The bucket size grows with the time range so that all of it is shown.
+func (m *DashboardModel) renderEventHeatmapSection(width int) string {
+	buckets, step := m.eventBuckets(eventHeatmapColumns)
+
+	title := fmt.Sprintf("Severity Activity Heatmap (Event Time, %s per Column)", formatStep(step))
+	if len(buckets) > 0 {
+		first := buckets[0].Timestamp
+		last := buckets[len(buckets)-1].Timestamp.Add(step)
+		title = fmt.Sprintf("Severity Activity Heatmap (Event Time %s - %s, %s per Column)",
+			first.Format("2006-01-02 15:04"), last.Format("2006-01-02 15:04"), formatStep(step))
+	}
+	titleContent := chartTitleStyle.Render(title)
+
+	// Time labels every 10 columns, dates once buckets span hours
+	labelLayout := "15:04"
+	if step >= 6*time.Hour {
+		labelLayout = "01-02"
+	}
+	labels := []byte(strings.Repeat(" ", len(buckets)+len(labelLayout)))
+	for i := 0; i < len(buckets); i += 10 {
+		copy(labels[i:], buckets[i].Timestamp.Format(labelLayout))
+	}
+	timeHeader := fmt.Sprintf("%-16s", "Event time:") + strings.TrimRight(string(labels), " ")
+
+	var contentLines []string
+	contentLines = append(contentLines, timeHeader)
+	contentLines = append(contentLines, strings.Repeat("─", max(len(timeHeader), 16+len(buckets))))
+
+	for _, severity := range heatmapSeverities {
+		total, maxCount := 0, 0
+		for _, bucket := range buckets {
+			count := bucket.Counts.Count(severity)
+			total += count
+			maxCount = max(maxCount, count)
+		}
+
+		severityWithCount := fmt.Sprintf("%s (%d)", severity, total)
+		line := lipgloss.NewStyle().Foreground(getSeverityColor(severity)).Bold(true).Render(fmt.Sprintf("%-12s", severityWithCount)) + "    "
+
+		var anomalies map[time.Time]bool
+		if len(buckets) > 0 {
+			anomalies = m.heatmapAnomalies(severity, buckets, step, buckets[len(buckets)-1].Timestamp)
+		}
+		for _, bucket := range buckets {
+			line += heatmapCell(severity, bucket.Counts.Count(severity), maxCount, anomalies[bucket.Timestamp])
+		}
+		contentLines = append(contentLines, line)
+	}
+
+	contentLines = append(contentLines, "")
+	if len(buckets) == 0 {
+		contentLines = append(contentLines, helpStyle.Render("No log entries yet"))
+	}
+	contentLines = append(contentLines, heatmapLegend)
+
+	sectionContent := lipgloss.JoinVertical(lipgloss.Left, titleContent, strings.Join(contentLines, "\n"))
+
+	return sectionStyle.
+		Width(width).
+		Render(sectionContent)
+}
+
+// formatStep formats a bucket size, e.g. 5m, 2h or 7d
+func formatStep(step time.Duration) string {
+	if step > 24*time.Hour && step%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", int(step.Hours())/24)
+	}
+	if step >= time.Hour {
+		return fmt.Sprintf("%dh", int(step.Hours()))
+	}
+	return fmt.Sprintf("%dm", int(step.Minutes()))
+}
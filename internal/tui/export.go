@@ -0,0 +1,268 @@
+package tui
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// ExportFormat identifies the file format used when exporting log entries
+type ExportFormat int
+
+const (
+	ExportFormatRaw ExportFormat = iota
+	ExportFormatNDJSON
+	ExportFormatOTLP
+)
+
+// exportFormats lists the formats in the order shown in the export modal
+var exportFormats = []struct {
+	format    ExportFormat
+	label     string
+	extension string
+}{
+	{ExportFormatRaw, "Raw lines", "log"},
+	{ExportFormatNDJSON, "NDJSON (normalized entries)", "ndjson"},
+	{ExportFormatOTLP, "OTLP JSON (LogsData)", "json"},
+}
+
+// exportWindows lists the time windows offered in the export modal (0 means everything in view)
+var exportWindows = []struct {
+	label  string
+	window time.Duration
+}{
+	{"Entire filtered view", 0},
+	{"Last 5 minutes", 5 * time.Minute},
+	{"Last 15 minutes", 15 * time.Minute},
+	{"Last hour", time.Hour},
+}
+
+// exportedLogEntry is the NDJSON representation of a LogEntry
+type exportedLogEntry struct {
+	Timestamp     time.Time         `json:"timestamp"`
+	OrigTimestamp *time.Time        `json:"original_timestamp,omitempty"`
+	Severity      string            `json:"severity"`
+	Message       string            `json:"message"`
+	RawLine       string            `json:"raw,omitempty"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+}
+
+// entryTime returns the original log time if known, otherwise the receive time
+func entryTime(entry LogEntry) time.Time {
+	if !entry.OrigTimestamp.IsZero() {
+		return entry.OrigTimestamp
+	}
+	return entry.Timestamp
+}
+
+// entriesInWindow returns the entries within window of the newest entry (0 returns all)
+func entriesInWindow(entries []LogEntry, window time.Duration) []LogEntry {
+	if window <= 0 || len(entries) == 0 {
+		return entries
+	}
+
+	// Anchor the window at the newest entry so historical files export sensibly
+	newest := entryTime(entries[0])
+	for _, entry := range entries[1:] {
+		if t := entryTime(entry); t.After(newest) {
+			newest = t
+		}
+	}
+	cutoff := newest.Add(-window)
+
+	result := make([]LogEntry, 0, len(entries))
+	for _, entry := range entries {
+		if !entryTime(entry).Before(cutoff) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// defaultExportPath builds a timestamped file name for the given format
+func defaultExportPath(format ExportFormat) string {
+	extension := "log"
+	for _, f := range exportFormats {
+		if f.format == format {
+			extension = f.extension
+		}
+	}
+	return fmt.Sprintf("gonzo-export-%s.%s", time.Now().Format("20060102-150405"), extension)
+}
+
+// ExportLogEntries writes entries to path in the given format
+func ExportLogEntries(entries []LogEntry, format ExportFormat, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	switch format {
+	case ExportFormatNDJSON:
+		err = writeNDJSON(writer, entries)
+	case ExportFormatOTLP:
+		err = writeOTLPJSON(writer, entries)
+	default:
+		err = writeRawLines(writer, entries)
+	}
+
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func writeRawLines(w *bufio.Writer, entries []LogEntry) error {
+	for _, entry := range entries {
+		line := entry.RawLine
+		if line == "" {
+			line = entry.Message
+		}
+		if _, err := w.WriteString(line + "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeNDJSON(w *bufio.Writer, entries []LogEntry) error {
+	encoder := json.NewEncoder(w)
+	for _, entry := range entries {
+		exported := exportedLogEntry{
+			Timestamp:  entry.Timestamp,
+			Severity:   entry.Severity,
+			Message:    entry.Message,
+			RawLine:    entry.RawLine,
+			Attributes: entry.Attributes,
+		}
+		if !entry.OrigTimestamp.IsZero() {
+			orig := entry.OrigTimestamp
+			exported.OrigTimestamp = &orig
+		}
+		if err := encoder.Encode(exported); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeOTLPJSON writes a single OTLP LogsData document, grouping entries into
+// one ResourceLogs per service
+func writeOTLPJSON(w *bufio.Writer, entries []LogEntry) error {
+	data, err := protojson.MarshalOptions{Indent: "  "}.Marshal(buildOTLPLogsData(entries))
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	_, err = w.WriteString("\n")
+	return err
+}
+
+// resourceAttributeKeys are attributes promoted to the OTLP resource on export
+var resourceAttributeKeys = []string{"service.name", "host.name"}
+
+func buildOTLPLogsData(entries []LogEntry) *logspb.LogsData {
+	byResource := make(map[string]*logspb.ResourceLogs)
+	var order []string
+
+	for _, entry := range entries {
+		// Resource identity is the combination of promoted attribute values
+		var idParts []string
+		var resourceAttrs []*commonpb.KeyValue
+		for _, key := range resourceAttributeKeys {
+			if value, ok := entry.Attributes[key]; ok {
+				idParts = append(idParts, key+"="+value)
+				resourceAttrs = append(resourceAttrs, stringKeyValue(key, value))
+			}
+		}
+		id := strings.Join(idParts, ",")
+
+		resourceLogs, ok := byResource[id]
+		if !ok {
+			resourceLogs = &logspb.ResourceLogs{
+				Resource:  &resourcepb.Resource{Attributes: resourceAttrs},
+				ScopeLogs: []*logspb.ScopeLogs{{Scope: &commonpb.InstrumentationScope{Name: "gonzo"}}},
+			}
+			byResource[id] = resourceLogs
+			order = append(order, id)
+		}
+
+		scopeLogs := resourceLogs.ScopeLogs[0]
+		scopeLogs.LogRecords = append(scopeLogs.LogRecords, logEntryToOTLPRecord(entry))
+	}
+
+	logsData := &logspb.LogsData{}
+	for _, id := range order {
+		logsData.ResourceLogs = append(logsData.ResourceLogs, byResource[id])
+	}
+	return logsData
+}
+
+func logEntryToOTLPRecord(entry LogEntry) *logspb.LogRecord {
+	record := &logspb.LogRecord{
+		ObservedTimeUnixNano: uint64(entry.Timestamp.UnixNano()),
+		SeverityText:         entry.Severity,
+		SeverityNumber:       severityTextToNumber(entry.Severity),
+		Body:                 &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: entry.Message}},
+	}
+	if !entry.OrigTimestamp.IsZero() {
+		record.TimeUnixNano = uint64(entry.OrigTimestamp.UnixNano())
+	}
+
+	// Stable attribute order keeps exports diffable
+	keys := make([]string, 0, len(entry.Attributes))
+	for key := range entry.Attributes {
+		if key == "service.name" || key == "host.name" {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		record.Attributes = append(record.Attributes, stringKeyValue(key, entry.Attributes[key]))
+	}
+
+	return record
+}
+
+func stringKeyValue(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+// severityTextToNumber maps a severity name to the base OTLP severity number
+func severityTextToNumber(severity string) logspb.SeverityNumber {
+	switch normalizeSeverityLevel(severity) {
+	case "TRACE":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_TRACE
+	case "DEBUG":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG
+	case "INFO":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_INFO
+	case "WARN":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_WARN
+	case "ERROR":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_ERROR
+	case "FATAL", "CRITICAL":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_FATAL
+	default:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED
+	}
+}
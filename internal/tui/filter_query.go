@@ -0,0 +1,599 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Filter query language
+//
+// The '/' filter accepts either a plain regex (matched against the raw line,
+// message and attribute keys/values) or a query expression such as:
+//
+//	severity=ERROR AND service.name=checkout AND NOT message~timeout
+//	severity>=WARN AND (k8s.namespace=prod OR k8s.namespace=staging)
+//	duration_ms>250 AND timestamp>15m
+//	timestamp>="2024-01-02 10:00" AND timestamp<"2024-01-02 11:00"
+//
+// Fields: severity/level, message/msg, raw/line, service, timestamp/time
+// (original log time), received (receive time), pattern (Drain3 pattern ID,
+// set by selecting a pattern in the patterns modal), source (input source:
+// stdin, file, otlp, syslog, vmlogs or k8s), file (name of the file read
+// from). Any other name is looked up in the attributes; use the attr. prefix
+// to force an attribute lookup.
+//
+// Operators: = != (case-insensitive equality), ~ !~ (regex), > >= < <=
+// (numeric, duration, severity level or time comparison).
+// Boolean operators: AND/&&, OR/||, NOT/!, and parentheses.
+// A bare word or quoted string matches like the plain regex filter.
+
+// filterNode is a node in a parsed filter query
+type filterNode interface {
+	matches(entry LogEntry) bool
+}
+
+type andNode struct{ left, right filterNode }
+type orNode struct{ left, right filterNode }
+type notNode struct{ child filterNode }
+
+// textNode matches a regex anywhere in the entry, like the plain regex filter
+type textNode struct{ regex *regexp.Regexp }
+
+// compareNode compares a single field against a value
+type compareNode struct {
+	field string
+	op    string
+	value string
+	regex *regexp.Regexp // For ~ and !~
+
+	// Pre-parsed forms of value, used by ordering comparisons
+	number      float64
+	isNumber    bool
+	duration    time.Duration
+	isDuration  bool
+	timeValue   time.Time
+	isTime      bool
+	severity    int
+	hasSeverity bool
+}
+
+func (n *andNode) matches(entry LogEntry) bool {
+	return n.left.matches(entry) && n.right.matches(entry)
+}
+
+func (n *orNode) matches(entry LogEntry) bool {
+	return n.left.matches(entry) || n.right.matches(entry)
+}
+
+func (n *notNode) matches(entry LogEntry) bool {
+	return !n.child.matches(entry)
+}
+
+func (n *textNode) matches(entry LogEntry) bool {
+	return entryMatchesRegex(entry, n.regex)
+}
+
+func (n *compareNode) matches(entry LogEntry) bool {
+	switch n.field {
+	case "timestamp", "received":
+		ts := entry.Timestamp
+		if n.field == "timestamp" && !entry.OrigTimestamp.IsZero() {
+			ts = entry.OrigTimestamp
+		}
+		return n.compareTime(ts)
+	}
+
+	actual, ok := n.fieldValue(entry)
+
+	switch n.op {
+	case "=":
+		return ok && n.equals(actual)
+	case "!=":
+		return !ok || !n.equals(actual)
+	case "~":
+		return ok && n.regex.MatchString(actual)
+	case "!~":
+		return !ok || !n.regex.MatchString(actual)
+	}
+
+	if !ok {
+		return false
+	}
+	cmp, comparable := n.compareOrdered(actual)
+	if !comparable {
+		return false
+	}
+	return orderingHolds(n.op, cmp)
+}
+
+// fieldValue resolves the field of the entry as a string
+func (n *compareNode) fieldValue(entry LogEntry) (string, bool) {
+	switch n.field {
+	case "severity":
+		return normalizeSeverityLevel(entry.Severity), true
+	case "message":
+		return entry.Message, true
+	case "raw":
+		return entry.RawLine, true
+	case "service":
+		return GetServiceName(entry), true
+	case "pattern":
+		if entry.PatternID == 0 {
+			return "", false
+		}
+		return strconv.FormatInt(entry.PatternID, 10), true
+	}
+
+	key := strings.TrimPrefix(n.field, "attr.")
+	value, ok := entry.Attributes[key]
+	return value, ok
+}
+
+func (n *compareNode) equals(actual string) bool {
+	if n.field == "severity" {
+		return actual == normalizeSeverityLevel(n.value)
+	}
+	return strings.EqualFold(actual, n.value)
+}
+
+// compareOrdered compares actual against the query value, returning -1, 0 or 1
+func (n *compareNode) compareOrdered(actual string) (int, bool) {
+	if n.field == "severity" {
+		rank, ok := severityRank[actual]
+		if !ok || !n.hasSeverity {
+			return 0, false
+		}
+		return compareInts(rank, n.severity), true
+	}
+
+	if n.isNumber {
+		if number, err := strconv.ParseFloat(strings.TrimSpace(actual), 64); err == nil {
+			return compareFloats(number, n.number), true
+		}
+	}
+
+	if n.isDuration {
+		if duration, err := time.ParseDuration(strings.TrimSpace(actual)); err == nil {
+			return compareInts(int(duration), int(n.duration)), true
+		}
+	}
+
+	return 0, false
+}
+
+// compareTime compares a timestamp against an absolute or relative query time
+func (n *compareNode) compareTime(ts time.Time) bool {
+	var target time.Time
+	switch {
+	case n.isTime:
+		target = n.timeValue
+	case n.isDuration:
+		// Relative values mean "this long ago", evaluated at match time
+		target = time.Now().Add(-n.duration)
+	default:
+		return false
+	}
+
+	switch n.op {
+	case "=":
+		return ts.Truncate(time.Second).Equal(target.Truncate(time.Second))
+	case "!=":
+		return !ts.Truncate(time.Second).Equal(target.Truncate(time.Second))
+	case ">", ">=", "<", "<=":
+		return orderingHolds(n.op, compareInts(int(ts.Sub(target)), 0))
+	}
+	return false
+}
+
+// severityRank orders normalized severities for >, <, etc.
+var severityRank = map[string]int{
+	"TRACE":    1,
+	"DEBUG":    2,
+	"INFO":     3,
+	"WARN":     4,
+	"ERROR":    5,
+	"CRITICAL": 6,
+	"FATAL":    7,
+}
+
+// fieldAliases maps accepted field names to their canonical form
+var fieldAliases = map[string]string{
+	"severity":  "severity",
+	"level":     "severity",
+	"message":   "message",
+	"msg":       "message",
+	"raw":       "raw",
+	"line":      "raw",
+	"service":   "service",
+	"timestamp": "timestamp",
+	"time":      "timestamp",
+	"received":  "received",
+	"pattern":   "pattern",
+	"source":    SourceAttribute,
+	"file":      FileNameAttribute,
+}
+
+// timeLayouts are the accepted absolute time formats for timestamp comparisons
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+func orderingHolds(op string, cmp int) bool {
+	switch op {
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// parseQueryTime parses an absolute time, or a time of day (today, local time)
+func parseQueryTime(value string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, true
+		}
+	}
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			now := time.Now()
+			return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// entryMatchesRegex checks the raw line, message and attribute keys/values against a regex
+func entryMatchesRegex(entry LogEntry, regex *regexp.Regexp) bool {
+	if regex.MatchString(entry.RawLine) || regex.MatchString(entry.Message) {
+		return true
+	}
+	for key, value := range entry.Attributes {
+		if regex.MatchString(key) || regex.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Lexer
+
+type queryTokenKind int
+
+const (
+	tokenEOF queryTokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLParen
+	tokenRParen
+)
+
+type queryToken struct {
+	kind  queryTokenKind
+	text  string
+	space bool // Whether whitespace preceded the token
+}
+
+// isQueryWordRune reports whether r can appear in an unquoted field or value
+func isQueryWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_.-:/@+*$", r)
+}
+
+// quoteQueryValue quotes a value for a filter query if it is not a single word
+func quoteQueryValue(value string) string {
+	if value != "" && strings.IndexFunc(value, func(r rune) bool { return !isQueryWordRune(r) }) < 0 {
+		return value
+	}
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
+
+func tokenizeFilterQuery(input string) ([]queryToken, error) {
+	var tokens []queryToken
+	runes := []rune(input)
+	space := false
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		if unicode.IsSpace(r) {
+			space = true
+			i++
+			continue
+		}
+
+		tok := queryToken{space: space}
+		space = false
+
+		switch {
+		case r == '(':
+			tok.kind, tok.text = tokenLParen, "("
+			i++
+		case r == ')':
+			tok.kind, tok.text = tokenRParen, ")"
+			i++
+		case r == '"' || r == '\'':
+			// Quoted string with backslash escapes for the quote character
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) && runes[j+1] == r {
+					j++
+				}
+				b.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tok.kind, tok.text = tokenString, b.String()
+			i = j + 1
+		case r == '&' && i+1 < len(runes) && runes[i+1] == '&':
+			tok.kind, tok.text = tokenAnd, "&&"
+			i += 2
+		case r == '|' && i+1 < len(runes) && runes[i+1] == '|':
+			tok.kind, tok.text = tokenOr, "||"
+			i += 2
+		case r == '!' && i+1 < len(runes) && (runes[i+1] == '=' || runes[i+1] == '~'):
+			tok.kind, tok.text = tokenOp, string(runes[i:i+2])
+			i += 2
+		case r == '!':
+			tok.kind, tok.text = tokenNot, "!"
+			i++
+		case r == '>' || r == '<':
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				tok.kind, tok.text = tokenOp, string(runes[i:i+2])
+				i += 2
+			} else {
+				tok.kind, tok.text = tokenOp, string(r)
+				i++
+			}
+		case r == '=' || r == '~':
+			tok.kind, tok.text = tokenOp, string(r)
+			i++
+			// Accept == as an alias for =
+			if r == '=' && i < len(runes) && runes[i] == '=' {
+				i++
+			}
+		case isQueryWordRune(r):
+			j := i
+			for j < len(runes) && isQueryWordRune(runes[j]) {
+				j++
+			}
+			tok.text = string(runes[i:j])
+			switch strings.ToUpper(tok.text) {
+			case "AND":
+				tok.kind = tokenAnd
+			case "OR":
+				tok.kind = tokenOr
+			case "NOT":
+				tok.kind = tokenNot
+			default:
+				tok.kind = tokenWord
+			}
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+
+		tokens = append(tokens, tok)
+	}
+
+	return append(tokens, queryToken{kind: tokenEOF}), nil
+}
+
+// Parser
+
+type filterQueryParser struct {
+	tokens []queryToken
+	pos    int
+
+	// structured is set once a comparison or boolean operator is parsed
+	structured bool
+}
+
+// parseFilterQuery parses a filter query expression. It returns an error when
+// the input is not a valid query or is just free text, in which case callers
+// should treat the input as a plain regex.
+func parseFilterQuery(input string) (filterNode, error) {
+	tokens, err := tokenizeFilterQuery(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterQueryParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q", p.peek().text)
+	}
+	if !p.structured {
+		return nil, fmt.Errorf("not a query expression")
+	}
+	return node, nil
+}
+
+func (p *filterQueryParser) peek() queryToken {
+	return p.tokens[p.pos]
+}
+
+func (p *filterQueryParser) next() queryToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// parseOr: and (OR and)*
+func (p *filterQueryParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		p.next()
+		p.structured = true
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseAnd: not (AND not)*
+func (p *filterQueryParser) parseAnd() (filterNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		p.next()
+		p.structured = true
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseNot: NOT not | primary
+func (p *filterQueryParser) parseNot() (filterNode, error) {
+	if p.peek().kind == tokenNot {
+		p.next()
+		p.structured = true
+		child, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{child: child}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary: ( expr ) | field op value | text
+func (p *filterQueryParser) parsePrimary() (filterNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenLParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next().kind != tokenRParen {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		return node, nil
+
+	case tokenWord, tokenString:
+		if tok.kind == tokenWord && p.peek().kind == tokenOp {
+			return p.parseComparison(tok.text)
+		}
+		regex, err := regexp.Compile(tok.text)
+		if err != nil {
+			return nil, err
+		}
+		return &textNode{regex: regex}, nil
+
+	case tokenEOF:
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+
+	return nil, fmt.Errorf("unexpected %q", tok.text)
+}
+
+func (p *filterQueryParser) parseComparison(field string) (filterNode, error) {
+	op := p.next().text
+
+	valueTok := p.next()
+	if valueTok.kind != tokenWord && valueTok.kind != tokenString {
+		return nil, fmt.Errorf("missing value after %s%s", field, op)
+	}
+	p.structured = true
+
+	canonical, ok := fieldAliases[strings.ToLower(field)]
+	if !ok {
+		canonical = field
+	}
+
+	node := &compareNode{field: canonical, op: op, value: valueTok.text}
+
+	switch op {
+	case "~", "!~":
+		if canonical == "timestamp" || canonical == "received" {
+			return nil, fmt.Errorf("%s does not support %s, use =, !=, <, <=, > or >=", field, op)
+		}
+		regex, err := regexp.Compile(node.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex for %s: %w", field, err)
+		}
+		node.regex = regex
+		return node, nil
+	}
+
+	if number, err := strconv.ParseFloat(node.value, 64); err == nil {
+		node.number, node.isNumber = number, true
+	}
+	if duration, err := time.ParseDuration(strings.TrimPrefix(node.value, "-")); err == nil {
+		node.duration, node.isDuration = duration, true
+	}
+	if rank, ok := severityRank[normalizeSeverityLevel(node.value)]; ok {
+		node.severity, node.hasSeverity = rank, true
+	}
+
+	switch canonical {
+	case "timestamp", "received":
+		node.timeValue, node.isTime = parseQueryTime(node.value)
+		if !node.isTime && !node.isDuration {
+			return nil, fmt.Errorf("invalid time %q", node.value)
+		}
+	case "severity":
+		if op != "=" && op != "!=" && !node.hasSeverity {
+			return nil, fmt.Errorf("unknown severity %q", node.value)
+		}
+	}
+
+	return node, nil
+}
@@ -8,6 +8,71 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// singleLineMessage reduces a multi-line message (such as a stack trace) to its
+// first line so it fits on one row of the log list
+func singleLineMessage(message string) string {
+	first, rest, found := strings.Cut(message, "\n")
+	if !found {
+		return message
+	}
+	return fmt.Sprintf("%s [+%d lines]", strings.TrimRight(first, "\r"), strings.Count(rest, "\n")+1)
+}
+
+// maxFileTagLen is the longest file name shown in front of messages
+const maxFileTagLen = 16
+
+// fileTag returns the name of the file an entry was read from, shown in front of
+// the message once entries of more than one file were seen
+func (m *DashboardModel) fileTag(entry LogEntry) string {
+	name := entry.Attributes[FileNameAttribute]
+	if name == "" || len(m.lifetimeAttrKeyCounts[FilePathAttribute]) < 2 {
+		return ""
+	}
+	if len(name) > maxFileTagLen {
+		name = name[:maxFileTagLen-3] + "..."
+	}
+	return name
+}
+
+// fileColor returns the color of a file's tag. Files get the colors in the order
+// their first entry arrived, so the first files never share a color.
+func (m *DashboardModel) fileColor(path string) lipgloss.Color {
+	palette := []lipgloss.Color{ColorBlue, ColorGreen, ColorOrange, ColorPink, ColorYellow, ColorGray}
+	return palette[m.fileColorIndex[path]%len(palette)]
+}
+
+// assignFileColor gives the file of an entry the next color if it has none yet
+func (m *DashboardModel) assignFileColor(entry LogEntry) {
+	path := entry.Attributes[FilePathAttribute]
+	if path == "" {
+		return
+	}
+	if _, exists := m.fileColorIndex[path]; !exists {
+		m.fileColorIndex[path] = len(m.fileColorIndex)
+	}
+}
+
+// selectedRowMessage returns the truncated single-line message for a selected row, prefixed
+// with the file tag and new pattern badge when they apply
+func (m *DashboardModel) selectedRowMessage(entry LogEntry, maxMessageLen int) string {
+	message := singleLineMessage(entry.Message)
+	prefix := ""
+	if tag := m.fileTag(entry); tag != "" {
+		prefix = tag + " "
+	}
+	if m.isNewPatternEntry(entry) {
+		prefix += newPatternBadge + " "
+	}
+	maxMessageLen -= len(prefix)
+	if maxMessageLen < 10 {
+		maxMessageLen = 10
+	}
+	if len(message) > maxMessageLen {
+		message = message[:maxMessageLen-3] + "..."
+	}
+	return prefix + message
+}
+
 // formatLogEntry formats a log entry with colors
 func (m *DashboardModel) formatLogEntry(entry LogEntry, availableWidth int, isSelected bool) string {
 	// Use receive time for display
@@ -44,10 +109,7 @@ func (m *DashboardModel) formatLogEntry(entry LogEntry, availableWidth int, isSe
 				maxMessageLen = 10
 			}
 
-			message := entry.Message
-			if len(message) > maxMessageLen {
-				message = message[:maxMessageLen-3] + "..."
-			}
+			message := m.selectedRowMessage(entry, maxMessageLen)
 
 			logLine = fmt.Sprintf("%s %-5s %s %s %s", timestamp, severity, hostCol, serviceCol, message)
 		} else {
@@ -57,10 +119,7 @@ func (m *DashboardModel) formatLogEntry(entry LogEntry, availableWidth int, isSe
 				maxMessageLen = 10
 			}
 
-			message := entry.Message
-			if len(message) > maxMessageLen {
-				message = message[:maxMessageLen-3] + "..."
-			}
+			message := m.selectedRowMessage(entry, maxMessageLen)
 
 			logLine = fmt.Sprintf("%s %-5s %s", timestamp, severity, message)
 		}
@@ -113,9 +172,17 @@ func (m *DashboardModel) formatLogEntry(entry LogEntry, availableWidth int, isSe
 	}
 
 	// Truncate message if too long
-	message := entry.Message
+	message := singleLineMessage(entry.Message)
 
 	maxMessageLen := availableWidth - 18 - columnsWidth // Account for timestamp, severity, and columns
+	isNewPattern := m.isNewPatternEntry(entry)
+	if isNewPattern {
+		maxMessageLen -= len(newPatternBadge) + 1
+	}
+	fileTag := m.fileTag(entry)
+	if fileTag != "" {
+		maxMessageLen -= len(fileTag) + 1
+	}
 	if maxMessageLen < 10 {
 		maxMessageLen = 10 // Absolute minimum
 	}
@@ -128,6 +195,18 @@ func (m *DashboardModel) formatLogEntry(entry LogEntry, availableWidth int, isSe
 		message = m.highlightText(message, m.searchTerm)
 	}
 
+	// Highlight entries that belong to a pattern first seen after the baseline
+	if isNewPattern {
+		message = lipgloss.NewStyle().Foreground(ColorYellow).Bold(true).Render(newPatternBadge) + " " + message
+		styledTimestamp = lipgloss.NewStyle().Foreground(ColorYellow).Render(timestamp)
+	}
+
+	// Tag entries with their file, colored per file, when reading several files
+	if fileTag != "" {
+		color := m.fileColor(entry.Attributes[FilePathAttribute])
+		message = lipgloss.NewStyle().Foreground(color).Render(fileTag) + " " + message
+	}
+
 	// Create the complete log line
 	var logLine string
 	if m.showColumns {
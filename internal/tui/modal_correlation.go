@@ -0,0 +1,366 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultCorrelationKeys are the attributes the correlation modal groups logs by
+// unless configured otherwise
+var DefaultCorrelationKeys = []string{TraceIDAttribute, "request_id", "correlation_id"}
+
+// Correlation group orderings, toggled with 's' in the correlation modal
+const (
+	correlationSortErrors = iota
+	correlationSortDuration
+	correlationSortCount
+	correlationSortOrders // Number of orderings
+)
+
+// correlationTimelineWidth is the number of cells of a group's timeline
+const correlationTimelineWidth = 24
+
+// correlationGroup is the buffered logs sharing one value of the correlation key
+type correlationGroup struct {
+	id       string
+	entries  []LogEntry // Ordered by time
+	first    time.Time
+	last     time.Time
+	errors   int
+	services []string
+}
+
+// duration returns the time from the first to the last log of the group
+func (g correlationGroup) duration() time.Duration {
+	return g.last.Sub(g.first)
+}
+
+// SetCorrelationKeys sets the attributes the correlation modal groups logs by
+func (m *DashboardModel) SetCorrelationKeys(keys []string) {
+	var cleaned []string
+	for _, key := range keys {
+		if key = strings.TrimSpace(key); key != "" {
+			cleaned = append(cleaned, key)
+		}
+	}
+	if len(cleaned) > 0 {
+		m.correlationKeys = cleaned
+	}
+	m.correlationKeyIndex = 0
+}
+
+// correlationKey returns the attribute the correlation modal currently groups by
+func (m *DashboardModel) correlationKey() string {
+	if len(m.correlationKeys) == 0 {
+		return DefaultCorrelationKeys[0]
+	}
+	return m.correlationKeys[m.correlationKeyIndex%len(m.correlationKeys)]
+}
+
+// openCorrelationModal shows the correlation modal, grouping by the first key
+// that any buffered log carries
+func (m *DashboardModel) openCorrelationModal() {
+	m.showCorrelationModal = true
+	m.correlationSelected = 0
+	m.infoViewport.GotoTop()
+
+	for i, key := range m.correlationKeys {
+		for _, entry := range m.allLogEntries {
+			if entry.Attributes[key] != "" {
+				m.correlationKeyIndex = i
+				return
+			}
+		}
+	}
+}
+
+// isErrorSeverity reports whether a severity counts as an error
+func isErrorSeverity(severity string) bool {
+	switch normalizeSeverityLevel(severity) {
+	case "ERROR", "FATAL", "CRITICAL":
+		return true
+	}
+	return false
+}
+
+// correlationGroups groups the buffered logs by the value of the correlation key,
+// in the selected order
+func (m *DashboardModel) correlationGroups() []correlationGroup {
+	key := m.correlationKey()
+
+	index := make(map[string]int)
+	var groups []correlationGroup
+	for _, entry := range m.allLogEntries {
+		id := entry.Attributes[key]
+		if id == "" {
+			continue
+		}
+		i, ok := index[id]
+		if !ok {
+			i = len(groups)
+			index[id] = i
+			groups = append(groups, correlationGroup{id: id})
+		}
+		groups[i].entries = append(groups[i].entries, entry)
+	}
+
+	for i := range groups {
+		group := &groups[i]
+		sort.SliceStable(group.entries, func(a, b int) bool {
+			return entryTime(group.entries[a]).Before(entryTime(group.entries[b]))
+		})
+		group.first = entryTime(group.entries[0])
+		group.last = entryTime(group.entries[len(group.entries)-1])
+
+		seen := make(map[string]bool)
+		for _, entry := range group.entries {
+			if isErrorSeverity(entry.Severity) {
+				group.errors++
+			}
+			if service := GetServiceName(entry); service != "unknown" && !seen[service] {
+				seen[service] = true
+				group.services = append(group.services, service)
+			}
+		}
+	}
+
+	sort.SliceStable(groups, func(a, b int) bool {
+		ga, gb := groups[a], groups[b]
+		switch m.correlationSort {
+		case correlationSortDuration:
+			if ga.duration() != gb.duration() {
+				return ga.duration() > gb.duration()
+			}
+		case correlationSortCount:
+			if len(ga.entries) != len(gb.entries) {
+				return len(ga.entries) > len(gb.entries)
+			}
+		}
+		if ga.errors != gb.errors {
+			return ga.errors > gb.errors
+		}
+		if ga.duration() != gb.duration() {
+			return ga.duration() > gb.duration()
+		}
+		return ga.id < gb.id
+	})
+	return groups
+}
+
+// moveCorrelationSelection moves the selected group by delta rows and keeps it in view
+func (m *DashboardModel) moveCorrelationSelection(delta int) {
+	count := len(m.correlationGroups())
+	if count == 0 {
+		m.correlationSelected = 0
+		return
+	}
+	m.correlationSelected = max(0, min(count-1, m.correlationSelected+delta))
+
+	// The header takes two lines, then one line per group
+	line := m.correlationSelected + 2
+	if line < m.infoViewport.YOffset+2 {
+		m.infoViewport.SetYOffset(max(line-2, 0))
+	} else if m.infoViewport.Height > 0 && line >= m.infoViewport.YOffset+m.infoViewport.Height {
+		m.infoViewport.SetYOffset(line - m.infoViewport.Height + 1)
+	}
+}
+
+// filterBySelectedCorrelation filters the log view to the selected group and
+// closes the modal
+func (m *DashboardModel) filterBySelectedCorrelation() {
+	groups := m.correlationGroups()
+	if m.correlationSelected < 0 || m.correlationSelected >= len(groups) {
+		return
+	}
+
+	value := quoteQueryValue(groups[m.correlationSelected].id)
+	m.filterInput.SetValue(fmt.Sprintf("attr.%s=%s", m.correlationKey(), value))
+	m.applyFilterInput()
+	m.updateFilteredView()
+	m.showCorrelationModal = false
+}
+
+// renderCorrelationModal renders the correlation modal: the buffered logs
+// grouped by a correlation key, with the selected group's logs below
+func (m *DashboardModel) renderCorrelationModal() string {
+	modalWidth := m.width - 8
+	modalHeight := m.height - 4
+
+	contentWidth := modalWidth - 4
+	contentHeight := modalHeight - 4
+
+	groups := m.correlationGroups()
+	m.correlationSelected = max(0, min(m.correlationSelected, len(groups)-1))
+
+	// The selected group's logs take the lower part of the modal
+	listHeight := contentHeight
+	var detailPane string
+	if len(groups) > 0 {
+		available := contentHeight - 2 // The second pane's border
+		listHeight = max(available*3/5, 3)
+		detailHeight := max(available-listHeight, 3)
+		detail := m.renderCorrelationGroupDetail(groups[m.correlationSelected], contentWidth, detailHeight)
+		detailPane = lipgloss.NewStyle().
+			Width(contentWidth).
+			Height(detailHeight).
+			Border(lipgloss.NormalBorder()).
+			BorderForeground(ColorGray).
+			Render(detail)
+	}
+
+	m.infoViewport.Width = contentWidth
+	m.infoViewport.Height = listHeight
+	m.infoViewport.SetContent(m.renderCorrelationList(groups, contentWidth))
+
+	listPane := lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(listHeight).
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(ColorGray).
+		Render(m.infoViewport.View())
+
+	sortLabels := []string{"errors", "duration", "log count"}
+	titleText := fmt.Sprintf("Correlation by %s (%d groups, sorted by %s)", m.correlationKey(), len(groups), sortLabels[m.correlationSort])
+	header := lipgloss.NewStyle().
+		Width(contentWidth).
+		Foreground(ColorBlue).
+		Bold(true).
+		Render(titleText)
+
+	statusBar := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Render("↑↓: Select • Tab: Next key • s: Sort • Enter: Filter logs by group • ESC: Close")
+
+	parts := []string{header, listPane}
+	if detailPane != "" {
+		parts = append(parts, detailPane)
+	}
+	parts = append(parts, statusBar)
+	modal := lipgloss.JoinVertical(lipgloss.Left, parts...)
+
+	finalModal := lipgloss.NewStyle().
+		Width(modalWidth).
+		Height(modalHeight).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Render(modal)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, finalModal)
+}
+
+// renderCorrelationList renders one line per group: errors, duration, log
+// count, ID, a timeline of its logs and the services involved
+func (m *DashboardModel) renderCorrelationList(groups []correlationGroup, contentWidth int) string {
+	grayStyle := lipgloss.NewStyle().Foreground(ColorGray)
+
+	if len(groups) == 0 {
+		keys := strings.Join(m.correlationKeys, ", ")
+		return helpStyle.Render(fmt.Sprintf("No buffered logs carry a %s attribute (Tab switches between %s)", m.correlationKey(), keys))
+	}
+
+	const idWidth = 24
+	var lines []string
+	lines = append(lines, grayStyle.Render(fmt.Sprintf("  %6s %9s %5s  %-*s  %-*s  %s",
+		"ERRORS", "DURATION", "LOGS", idWidth, "ID", correlationTimelineWidth, "TIMELINE", "SERVICES")))
+	lines = append(lines, grayStyle.Render(strings.Repeat("─", max(contentWidth-2, 10))))
+
+	servicesWidth := max(contentWidth-(2+6+1+9+1+5+2+idWidth+2+correlationTimelineWidth+2)-2, 10)
+	for i, group := range groups {
+		id := group.id
+		if len(id) > idWidth {
+			id = id[:idWidth-3] + "..."
+		}
+		services := strings.Join(group.services, ", ")
+		if len(services) > servicesWidth {
+			services = services[:servicesWidth-3] + "..."
+		}
+
+		errors := fmt.Sprintf("%6d", group.errors)
+		if group.errors > 0 {
+			errors = lipgloss.NewStyle().Foreground(ColorRed).Bold(true).Render(errors)
+		}
+		row := fmt.Sprintf(" %s %9s %5d  %-*s  %s  %s",
+			errors, formatSpanDuration(group.duration()), len(group.entries),
+			idWidth, id, correlationTimeline(group), grayStyle.Render(services))
+
+		prefix := "  "
+		if i == m.correlationSelected {
+			prefix = lipgloss.NewStyle().Foreground(ColorBlue).Bold(true).Render("▶ ")
+		}
+		lines = append(lines, prefix+row)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// correlationTimeline places the logs of a group on a fixed width timeline
+// from its first to its last log. Each cell shows its most severe log.
+func correlationTimeline(group correlationGroup) string {
+	cells := make([]string, correlationTimelineWidth)
+	ranks := make([]int, correlationTimelineWidth)
+
+	span := group.duration()
+	for _, entry := range group.entries {
+		cell := 0
+		if span > 0 {
+			cell = int(float64(entryTime(entry).Sub(group.first)) / float64(span) * float64(correlationTimelineWidth-1))
+		}
+		cell = max(0, min(cell, correlationTimelineWidth-1))
+
+		severity := normalizeSeverityLevel(entry.Severity)
+		if rank := severityRank[severity]; cells[cell] == "" || rank > ranks[cell] {
+			ranks[cell] = rank
+			cells[cell] = severity
+		}
+	}
+
+	var timeline strings.Builder
+	for _, severity := range cells {
+		if severity == "" {
+			timeline.WriteString(lipgloss.NewStyle().Foreground(ColorGray).Render("·"))
+			continue
+		}
+		timeline.WriteString(lipgloss.NewStyle().Foreground(getSeverityColor(severity)).Render("●"))
+	}
+	return timeline.String()
+}
+
+// renderCorrelationGroupDetail lists the logs of a group with their offset from
+// the group's first log
+func (m *DashboardModel) renderCorrelationGroupDetail(group correlationGroup, contentWidth, height int) string {
+	grayStyle := lipgloss.NewStyle().Foreground(ColorGray)
+
+	var lines []string
+	lines = append(lines, lipgloss.NewStyle().Foreground(ColorBlue).Bold(true).Render(
+		fmt.Sprintf("%s=%s", m.correlationKey(), group.id))+
+		grayStyle.Render(fmt.Sprintf("  %d logs, %d errors, %s, %s", len(group.entries), group.errors,
+			formatSpanDuration(group.duration()), group.first.Format("2006-01-02 15:04:05.000"))))
+
+	shown := group.entries
+	if height > 1 && len(shown) > height-1 {
+		shown = shown[:height-2]
+	}
+	for _, entry := range shown {
+		severity := normalizeSeverityLevel(entry.Severity)
+		prefix := fmt.Sprintf("+%-9s %-5s ", formatSpanDuration(entryTime(entry).Sub(group.first)), severity)
+		service := GetServiceName(entry)
+		if service == "unknown" {
+			service = ""
+		} else {
+			service = "[" + service + "] "
+		}
+		message := entry.Message
+		if room := contentWidth - len(prefix) - len(service) - 2; room > 3 && len(message) > room {
+			message = message[:room-3] + "..."
+		}
+		lines = append(lines, lipgloss.NewStyle().Foreground(getSeverityColor(severity)).Render(prefix)+
+			grayStyle.Render(service)+message)
+	}
+	if len(shown) < len(group.entries) {
+		lines = append(lines, grayStyle.Render(fmt.Sprintf("... %d more (Enter filters the log view to this group)", len(group.entries)-len(shown))))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -94,6 +94,10 @@ func (m *DashboardModel) renderCountsModalContent(contentWidth int) string {
 
 // renderHeatmapSection renders the severity heatmap chart
 func (m *DashboardModel) renderHeatmapSection(width int) string {
+	if m.eventTimeAxis {
+		return m.renderEventHeatmapSection(width)
+	}
+
 	// Use chartTitleStyle for consistent title formatting
 	titleContent := chartTitleStyle.Render("Severity Activity Heatmap (Last 60 Minutes)")
 
@@ -133,12 +137,8 @@ func (m *DashboardModel) renderHeatmapSection(width int) string {
 	contentLines = append(contentLines, timeHeader)
 	contentLines = append(contentLines, strings.Repeat("─", len(timeHeader)))
 
-	// Get severity order and colors
-	severities := []string{"FATAL", "ERROR", "WARN", "INFO", "DEBUG", "TRACE"}
-	colors := map[string]lipgloss.Color{
-		"FATAL": ColorRed, "ERROR": ColorRed, "WARN": ColorOrange,
-		"INFO": ColorBlue, "DEBUG": ColorGray, "TRACE": ColorGray,
-	}
+	// Get severity order
+	severities := heatmapSeverities
 
 	// Calculate max count per severity for individual scaling
 	maxCounts := make(map[string]int)
@@ -206,6 +206,7 @@ func (m *DashboardModel) renderHeatmapSection(width int) string {
 
 		// Align data with time header - "Time (mins ago):" is 16 chars, so we need 16 chars total
 		line := coloredLabel + "    " // 12 + 4 = 16 to match header
+		anomalies := m.heatmapAnomalies(severity, m.heatmapData, time.Minute, now.Truncate(time.Minute))
 
 		// For each minute in the last 60 minutes
 		// i=0 represents the current minute and will show real-time updates
@@ -214,10 +215,8 @@ func (m *DashboardModel) renderHeatmapSection(width int) string {
 
 			// Find data for this exact minute
 			var minuteActivity int
-			found := false
 			for _, minute := range m.heatmapData {
 				if minute.Timestamp.Equal(minuteTime) {
-					found = true
 					switch severity {
 					case "FATAL":
 						minuteActivity = minute.Counts.Fatal + minute.Counts.Critical
@@ -236,38 +235,14 @@ func (m *DashboardModel) renderHeatmapSection(width int) string {
 				}
 			}
 
-			// Convert to visual representation using per-severity scaling
-			var symbol string
-			if !found || minuteActivity == 0 {
-				symbol = "." // Single dot for no data
-			} else {
-				intensity := float64(minuteActivity) / float64(maxCounts[severity])
-				if intensity > 0.7 {
-					symbol = "█"
-				} else if intensity > 0.4 {
-					symbol = "▓"
-				} else if intensity > 0.1 {
-					symbol = "▒"
-				} else {
-					symbol = "░"
-				}
-
-			}
-
-			// Apply color styling only if there's data
-			if found && minuteActivity > 0 {
-				styledSymbol := lipgloss.NewStyle().Foreground(colors[severity]).Render(symbol)
-				line += styledSymbol
-			} else {
-				line += symbol // No color for dots
-			}
+			line += heatmapCell(severity, minuteActivity, maxCounts[severity], anomalies[minuteTime])
 		}
 
 		contentLines = append(contentLines, line)
 	}
 
 	contentLines = append(contentLines, "")
-	contentLines = append(contentLines, "Legend: █ High Activity  ▓ Medium Activity  ▒ Low Activity  . No Activity")
+	contentLines = append(contentLines, heatmapLegend)
 
 	content := strings.Join(contentLines, "\n")
 
@@ -279,6 +254,41 @@ func (m *DashboardModel) renderHeatmapSection(width int) string {
 		Render(sectionContent)
 }
 
+// heatmapSeverities are the heatmap rows, most severe first
+var heatmapSeverities = []string{"FATAL", "ERROR", "WARN", "INFO", "DEBUG", "TRACE"}
+
+// heatmapLegend explains the heatmap cells
+const heatmapLegend = "Legend: █ High Activity  ▓ Medium Activity  ▒ Low Activity  . No Activity  ! Anomalous Spike"
+
+// heatmapCell renders one heatmap cell, scaled by the maximum count of its severity
+func heatmapCell(severity string, count, maxCount int, anomalous bool) string {
+	if anomalous {
+		return lipgloss.NewStyle().Foreground(ColorYellow).Bold(true).Render("!")
+	}
+	if count == 0 {
+		return "." // Single dot for no data, no color
+	}
+
+	colors := map[string]lipgloss.Color{
+		"FATAL": ColorRed, "ERROR": ColorRed, "WARN": ColorOrange,
+		"INFO": ColorBlue, "DEBUG": ColorGray, "TRACE": ColorGray,
+	}
+
+	// Convert to visual representation using per-severity scaling
+	var symbol string
+	intensity := float64(count) / float64(max(maxCount, 1))
+	if intensity > 0.7 {
+		symbol = "█"
+	} else if intensity > 0.4 {
+		symbol = "▓"
+	} else if intensity > 0.1 {
+		symbol = "▒"
+	} else {
+		symbol = "░"
+	}
+	return lipgloss.NewStyle().Foreground(colors[severity]).Render(symbol)
+}
+
 // renderPatternsBySeveritySection renders patterns grouped by severity using drain3 data
 func (m *DashboardModel) renderPatternsBySeveritySection(width int) string {
 	// Use chartTitleStyle for consistent title formatting
@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Export modal focus targets
+const (
+	exportFocusFormat = iota
+	exportFocusWindow
+	exportFocusPath
+	exportFocusCount
+)
+
+// openExportModal resets the export modal to its defaults and shows it
+func (m *DashboardModel) openExportModal() {
+	m.showExportModal = true
+	m.exportFocus = exportFocusFormat
+	m.exportStatus = ""
+	m.exportPathInput.SetValue(defaultExportPath(exportFormats[m.exportFormatIndex].format))
+	m.exportPathInput.Blur()
+}
+
+// setExportFormat changes the format and keeps a generated file name's extension in sync
+func (m *DashboardModel) setExportFormat(index int) {
+	oldExtension := "." + exportFormats[m.exportFormatIndex].extension
+	m.exportFormatIndex = index
+
+	path := m.exportPathInput.Value()
+	if strings.HasPrefix(path, "gonzo-export-") && strings.HasSuffix(path, oldExtension) {
+		m.exportPathInput.SetValue(strings.TrimSuffix(path, oldExtension) + "." + exportFormats[index].extension)
+	}
+}
+
+// exportEntries returns the entries that the export modal will write
+func (m *DashboardModel) exportEntries() []LogEntry {
+	return entriesInWindow(m.logEntries, exportWindows[m.exportWindowIndex].window)
+}
+
+// runExport writes the selected entries and reports the result
+func (m *DashboardModel) runExport() {
+	path := strings.TrimSpace(m.exportPathInput.Value())
+	if path == "" {
+		m.exportStatus = "Enter a file path"
+		return
+	}
+
+	entries := m.exportEntries()
+	if len(entries) == 0 {
+		m.exportStatus = "Nothing to export in the selected window"
+		return
+	}
+
+	format := exportFormats[m.exportFormatIndex]
+	if err := ExportLogEntries(entries, format.format, path); err != nil {
+		m.exportStatus = fmt.Sprintf("Export failed: %v", err)
+		return
+	}
+
+	m.showExportModal = false
+	m.exportPathInput.Blur()
+	m.modalContent = fmt.Sprintf("Export Complete\n\nWrote %d log entries to:\n%s\n\nFormat: %s\nWindow: %s",
+		len(entries), path, format.label, exportWindows[m.exportWindowIndex].label)
+	m.showModal = true
+}
+
+// renderExportModal renders the export options modal
+func (m *DashboardModel) renderExportModal() string {
+	modalWidth := min(m.width-16, 70)
+	modalHeight := min(m.height-8, 22)
+
+	contentWidth := modalWidth - 4
+	contentHeight := modalHeight - 4
+
+	selectedStyle := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
+	sectionStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	focusedSectionStyle := lipgloss.NewStyle().Foreground(ColorGreen).Bold(true)
+
+	renderSectionTitle := func(title string, focus int) string {
+		if m.exportFocus == focus {
+			return focusedSectionStyle.Render("▸ " + title)
+		}
+		return sectionStyle.Render("  " + title)
+	}
+
+	var lines []string
+
+	lines = append(lines, renderSectionTitle("Format", exportFocusFormat))
+	for i, f := range exportFormats {
+		line := "    ( ) " + f.label
+		if i == m.exportFormatIndex {
+			line = selectedStyle.Render("    (•) " + f.label)
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "")
+
+	lines = append(lines, renderSectionTitle("Time window", exportFocusWindow))
+	for i, w := range exportWindows {
+		line := "    ( ) " + w.label
+		if i == m.exportWindowIndex {
+			line = selectedStyle.Render("    (•) " + w.label)
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "")
+
+	lines = append(lines, renderSectionTitle("File", exportFocusPath))
+	m.exportPathInput.Width = contentWidth - 8
+	lines = append(lines, "    "+m.exportPathInput.View())
+	lines = append(lines, "")
+
+	lines = append(lines, fmt.Sprintf("  %d of %d entries in view will be exported", len(m.exportEntries()), len(m.logEntries)))
+	if m.exportStatus != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(ColorRed).Render("  "+m.exportStatus))
+	}
+
+	contentPane := lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(contentHeight).
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(ColorBlue).
+		Render(strings.Join(lines, "\n"))
+
+	header := lipgloss.NewStyle().
+		Width(contentWidth).
+		Foreground(ColorBlue).
+		Bold(true).
+		Render("Export Filtered Logs")
+
+	statusBar := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Render("Tab: Next field • ↑↓: Change option • Enter: Export • ESC: Cancel")
+
+	modal := lipgloss.JoinVertical(lipgloss.Left, header, contentPane, statusBar)
+
+	finalModal := lipgloss.NewStyle().
+		Width(modalWidth).
+		Height(modalHeight).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Render(modal)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, finalModal)
+}
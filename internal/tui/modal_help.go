@@ -70,16 +70,24 @@ NAVIGATION:
   Escape         - Close modal/exit filter mode
 
 ACTIONS:
-  /              - Activate filter (regex supported)
+  /              - Activate filter (regex or query expression)
   s              - Search and highlight text in logs
   Ctrl+f         - Open severity filter modal
   f              - Open fullscreen log viewer modal
+  e              - Export filtered logs to a file (raw, NDJSON, OTLP JSON)
+  g              - Group logs by trace/request ID (correlation view)
   Space          - Pause/unpause UI updates
+  p              - Pause/resume the replay (--replay)
+  ]/}            - Skip the replay forward 1/10 minutes
   c              - Toggle Host/Service columns in log view
+  n              - Toggle New Patterns panel
+  a              - Toggle Attributes panel between top and numeric attributes
+  t              - Toggle charts between receive time and original log time
   r              - Reset all data (manual reset)
   u/U            - Cycle update intervals (forward/backward)
   i              - Show comprehensive statistics modal
   i              - AI analysis (when viewing log details)
+  t              - Filter logs by the trace (when viewing log details)
   m              - Switch AI model (shows available models)
   ? or h         - Toggle this help
   q/Ctrl+C       - Quit
@@ -92,8 +100,12 @@ LOG VIEWER NAVIGATION:
 
 SECTIONS:
   Words          - Most frequent words in logs
-  Attributes     - OTLP attributes by unique value count
-  Log Patterns   - Common log message patterns (Drain3)
+  Attributes     - OTLP attributes by unique value count, or the percentiles
+                   of numeric attributes ('a'); Enter on one shows its
+                   histograms over time
+  Log Patterns   - Common log message patterns (Drain3); Enter opens all
+                   patterns, Enter on a pattern filters logs to it and shows
+                   the values of its variable parameters
   Counts         - Log counts over time
   Logs           - Navigate and inspect individual log entries
 
@@ -103,6 +115,15 @@ FILTER & SEARCH:
   Severity (Ctrl+f): Filter by log severity levels
   Examples: "error", "k8s.*pod", "service.name", "host.name.*prod"
 
+  Query expressions are also accepted by the filter:
+    severity=ERROR AND service.name=checkout AND NOT message~timeout
+    severity>=WARN AND (k8s.namespace=prod OR k8s.namespace=staging)
+    duration_ms>250 AND timestamp>15m
+  Fields: severity, message, raw, service, timestamp, received, pattern,
+  source (stdin, file, otlp, syslog, vmlogs, k8s), file (file name),
+  or any attribute (attr.<key> forces an attribute lookup)
+  Operators: = != ~ !~ > >= < <=, AND/&&, OR/||, NOT/!, ( )
+
 AI ANALYSIS:
   Set environment variables for AI-powered log analysis:
   • OPENAI_API_KEY   - Your API key (required)
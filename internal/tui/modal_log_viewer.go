@@ -42,7 +42,7 @@ func (m *DashboardModel) renderLogViewerModal() string {
 	var statusLeft string
 
 	// Check for active filter/search (including while being typed)
-	hasActiveFilter := m.filterActive || m.filterRegex != nil || m.filterInput.Value() != ""
+	hasActiveFilter := m.filterActive || m.hasFilter() || m.filterInput.Value() != ""
 	hasActiveSearch := m.searchActive || m.searchTerm != "" || m.searchInput.Value() != ""
 
 	// Build status message
@@ -61,7 +61,7 @@ func (m *DashboardModel) renderLogViewerModal() string {
 			} else {
 				statusParts = append(statusParts, fmt.Sprintf("🔍 Filter: [%s] (editing)", filterValue))
 			}
-		} else if m.filterRegex != nil {
+		} else if m.hasFilter() {
 			// Filter applied
 			statusParts = append(statusParts, fmt.Sprintf("🔍 Filter: [%s] (%d/%d)",
 				m.filterInput.Value(), len(m.logEntries), len(m.allLogEntries)))
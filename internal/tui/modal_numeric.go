@@ -0,0 +1,375 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/memory"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// numericHistogramBins is the largest number of value ranges the histograms use
+const numericHistogramBins = 10
+
+// numericBarWidth is the width of the bars of the value distribution
+const numericBarWidth = 20
+
+// numericShades shade the heatmap cells from few to many values
+var numericShades = []string{"░", "▒", "▓", "█"}
+
+// sparkRunes draw percentile values over time
+var sparkRunes = []rune("▁▂▃▄▅▆▇█")
+
+// openNumericModal shows the numeric attributes modal with an attribute selected
+func (m *DashboardModel) openNumericModal(selected int) {
+	m.showNumericModal = true
+	m.numericSelected = selected
+	m.infoViewport.GotoTop()
+}
+
+// selectedNumericAttribute returns the attribute selected in the numeric modal
+func (m *DashboardModel) selectedNumericAttribute() *NumericAttribute {
+	attributes := m.getNumericAttributes()
+	if m.numericSelected < 0 || m.numericSelected >= len(attributes) {
+		return nil
+	}
+	return attributes[m.numericSelected]
+}
+
+// moveNumericSelection moves the selected attribute by delta rows
+func (m *DashboardModel) moveNumericSelection(delta int) {
+	count := len(m.getNumericAttributes())
+	m.numericSelected = max(0, min(count-1, m.numericSelected+delta))
+}
+
+// cycleNumericService shows the values of the next service of the selected attribute
+func (m *DashboardModel) cycleNumericService() {
+	if attr := m.selectedNumericAttribute(); attr != nil {
+		m.numericFilter.service = nextFilterValue(attr.services(), m.numericFilter.service)
+	}
+}
+
+// cycleNumericSeverity shows the values of the next severity of the selected attribute
+func (m *DashboardModel) cycleNumericSeverity() {
+	if attr := m.selectedNumericAttribute(); attr != nil {
+		m.numericFilter.severity = nextFilterValue(attr.severities(), m.numericFilter.severity)
+	}
+}
+
+// filterBySelectedNumeric filters the log view to the logs at or above the p99
+// of the selected attribute (within the service and severity shown) and
+// closes the modal
+func (m *DashboardModel) filterBySelectedNumeric() {
+	attr := m.selectedNumericAttribute()
+	if attr == nil {
+		return
+	}
+	values := attr.window(m.numericFilter)
+	if values.Count == 0 {
+		return
+	}
+
+	// Rounded down to two decimals, so the logs with the p99 value itself match
+	p99 := math.Floor(values.Quantile(0.99)*100) / 100
+	query := fmt.Sprintf("attr.%s>=%s", attr.Key, strconv.FormatFloat(p99, 'f', -1, 64))
+	if m.numericFilter.service != "" {
+		query += " AND service=" + quoteQueryValue(m.numericFilter.service)
+	}
+	if m.numericFilter.severity != "" {
+		query += " AND severity=" + m.numericFilter.severity
+	}
+	m.filterInput.SetValue(query)
+	m.applyFilterInput()
+	m.updateFilteredView()
+	m.showNumericModal = false
+}
+
+// numericFilterLabel describes the service and severity the numeric modal shows
+func (m *DashboardModel) numericFilterLabel() string {
+	service, severity := m.numericFilter.service, m.numericFilter.severity
+	if service == "" {
+		service = "all"
+	}
+	if severity == "" {
+		severity = "all"
+	}
+	return fmt.Sprintf("service: %s, severity: %s", service, severity)
+}
+
+// renderNumericModal renders the numeric attributes modal: the percentiles of
+// every numeric attribute, with the value distribution and its change over
+// time for the selected one below
+func (m *DashboardModel) renderNumericModal() string {
+	modalWidth := m.width - 8
+	modalHeight := m.height - 4
+
+	contentWidth := modalWidth - 4
+	contentHeight := modalHeight - 4
+
+	attributes := m.getNumericAttributes()
+	m.numericSelected = max(0, min(m.numericSelected, len(attributes)-1))
+
+	// The selected attribute's histograms take the lower part of the modal
+	listHeight := contentHeight
+	var detailPane string
+	if len(attributes) > 0 {
+		available := contentHeight - 2 // The second pane's border
+		listHeight = max(min(len(attributes)+2, available*2/5), 3)
+		detailHeight := max(available-listHeight, 3)
+		detail := m.renderNumericDetail(attributes[m.numericSelected], contentWidth, detailHeight)
+		detailPane = lipgloss.NewStyle().
+			Width(contentWidth).
+			Height(detailHeight).
+			MaxHeight(detailHeight + 2).
+			Border(lipgloss.NormalBorder()).
+			BorderForeground(ColorGray).
+			Render(detail)
+	}
+
+	m.infoViewport.Width = contentWidth
+	m.infoViewport.Height = listHeight
+	m.infoViewport.SetContent(m.renderNumericList(attributes, contentWidth))
+
+	// Keep the selected attribute in view, the header takes two lines
+	if line := m.numericSelected + 2; line >= m.infoViewport.YOffset+listHeight {
+		m.infoViewport.SetYOffset(line - listHeight + 1)
+	} else if line < m.infoViewport.YOffset+2 {
+		m.infoViewport.SetYOffset(max(line-2, 0))
+	}
+
+	listPane := lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(listHeight).
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(ColorGray).
+		Render(m.infoViewport.View())
+
+	header := lipgloss.NewStyle().
+		Width(contentWidth).
+		Foreground(ColorBlue).
+		Bold(true).
+		Render(fmt.Sprintf("Numeric Attributes (%d detected)", len(attributes)))
+
+	statusBar := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Render("↑↓: Select • s: Service • v: Severity • Enter: Filter logs at/above p99 • ESC: Close")
+
+	parts := []string{header, listPane}
+	if detailPane != "" {
+		parts = append(parts, detailPane)
+	}
+	parts = append(parts, statusBar)
+	modal := lipgloss.JoinVertical(lipgloss.Left, parts...)
+
+	finalModal := lipgloss.NewStyle().
+		Width(modalWidth).
+		Height(modalHeight).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Render(modal)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, finalModal)
+}
+
+// renderNumericList renders the lifetime percentiles of each numeric attribute
+func (m *DashboardModel) renderNumericList(attributes []*NumericAttribute, contentWidth int) string {
+	grayStyle := lipgloss.NewStyle().Foreground(ColorGray)
+
+	if len(attributes) == 0 {
+		return helpStyle.Render("No numeric attributes yet (attributes like duration_ms, status or bytes are detected automatically)")
+	}
+
+	keyWidth := max(min(contentWidth-2-7*9-10-2, 40), 10)
+	var lines []string
+	lines = append(lines, grayStyle.Render(fmt.Sprintf("  %-*s %9s %8s %8s %8s %8s %8s %8s %8s",
+		keyWidth, "ATTRIBUTE", "COUNT", "MIN", "P50", "P90", "P99", "MAX", "MEAN", "TEXT")))
+	lines = append(lines, grayStyle.Render(strings.Repeat("─", max(contentWidth-2, 10))))
+
+	for i, attr := range attributes {
+		key := attr.Key
+		if len(key) > keyWidth {
+			key = key[:keyWidth-3] + "..."
+		}
+		values := attr.Values
+		row := fmt.Sprintf("%-*s %9d %8s %8s %8s %8s %8s %8s %8d", keyWidth, key, values.Count,
+			memory.FormatNumber(values.Min), memory.FormatNumber(values.Quantile(0.5)),
+			memory.FormatNumber(values.Quantile(0.9)), memory.FormatNumber(values.Quantile(0.99)),
+			memory.FormatNumber(values.Max), memory.FormatNumber(values.Mean()), attr.Other)
+
+		prefix := "  "
+		if i == m.numericSelected {
+			prefix = lipgloss.NewStyle().Foreground(ColorBlue).Bold(true).Render("▶ ")
+		}
+		lines = append(lines, prefix+row)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderNumericDetail renders the retained values of an attribute within the
+// service and severity shown: their percentiles, their distribution, and a
+// heatmap of the distribution over time
+func (m *DashboardModel) renderNumericDetail(attr *NumericAttribute, contentWidth, height int) string {
+	grayStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	titleStyle := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
+
+	values := attr.window(m.numericFilter)
+	title := titleStyle.Render(attr.Key) + grayStyle.Render(fmt.Sprintf("  last %s, %s", formatStep(numericRetention), m.numericFilterLabel()))
+	if values.Count == 0 {
+		return title + "\n" + helpStyle.Render("No values for this service and severity (s and v change them)")
+	}
+
+	summary := fmt.Sprintf("n=%d  min %s  p50 %s  p90 %s  p99 %s  max %s  mean %s", values.Count,
+		memory.FormatNumber(values.Min), memory.FormatNumber(values.Quantile(0.5)),
+		memory.FormatNumber(values.Quantile(0.9)), memory.FormatNumber(values.Quantile(0.99)),
+		memory.FormatNumber(values.Max), memory.FormatNumber(values.Mean()))
+
+	// Title, summary, a blank line and the section titles, percentile rows and time axis
+	bins := max(min(numericHistogramBins, height-8), 1)
+	bounds := numericBounds(values, bins)
+
+	const labelWidth = 10
+	distributionWidth := labelWidth + 1 + numericBarWidth + 16
+	distribution := renderNumericDistribution(values, bounds, labelWidth)
+
+	timeWidth := contentWidth - distributionWidth - 4 - labelWidth - 1
+	var overTime string
+	if timeWidth >= 10 {
+		overTime = m.renderNumericOverTime(attr, bounds, labelWidth, timeWidth)
+	}
+
+	sections := lipgloss.NewStyle().Width(distributionWidth).Render(distribution)
+	if overTime != "" {
+		sections = lipgloss.JoinHorizontal(lipgloss.Top, sections, "    ", overTime)
+	}
+	return strings.Join([]string{title, summary, "", sections}, "\n")
+}
+
+// numericBounds returns the upper bounds of up to bins value ranges covering
+// the values: logarithmic when they span orders of magnitude, linear otherwise
+func numericBounds(values *memory.Sketch, bins int) []float64 {
+	low, high := values.Min, values.Max
+	if high <= low {
+		return []float64{high}
+	}
+
+	bounds := make([]float64, bins)
+	logarithmic := low > 0 && high/low >= 20
+	for i := range bounds {
+		fraction := float64(i+1) / float64(bins)
+		if logarithmic {
+			bounds[i] = low * math.Pow(high/low, fraction)
+		} else {
+			bounds[i] = low + (high-low)*fraction
+		}
+	}
+	bounds[bins-1] = high
+	return bounds
+}
+
+// renderNumericDistribution renders a horizontal histogram of the values, one
+// row per value range, largest values on top
+func renderNumericDistribution(values *memory.Sketch, bounds []float64, labelWidth int) string {
+	grayStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	counts := values.Histogram(bounds)
+
+	var maxCount int64
+	for _, count := range counts {
+		maxCount = max(maxCount, count)
+	}
+
+	lines := []string{chartTitleStyle.Render("Distribution")}
+	for i := len(bounds) - 1; i >= 0; i-- {
+		filled := 0
+		if maxCount > 0 {
+			filled = int(float64(counts[i]) / float64(maxCount) * numericBarWidth)
+			if filled == 0 && counts[i] > 0 {
+				filled = 1
+			}
+		}
+		bar := lipgloss.NewStyle().Foreground(ColorGreen).Render(strings.Repeat("█", filled)) +
+			grayStyle.Render(strings.Repeat("░", numericBarWidth-filled))
+		percent := float64(counts[i]) / float64(values.Count) * 100
+		lines = append(lines, fmt.Sprintf("%*s %s %6d %5.1f%%", labelWidth, "≤ "+memory.FormatNumber(bounds[i]), bar, counts[i], percent))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderNumericOverTime renders a heatmap of the value ranges over time, with
+// sparklines of the p50 and p99 below it
+func (m *DashboardModel) renderNumericOverTime(attr *NumericAttribute, bounds []float64, labelWidth, width int) string {
+	grayStyle := lipgloss.NewStyle().Foreground(ColorGray)
+
+	buckets, start, step := attr.buckets(m.numericFilter, width)
+	lines := []string{chartTitleStyle.Render(fmt.Sprintf("Over time (%s columns)", formatStep(step)))}
+
+	histograms := make([][]int64, len(buckets))
+	var maxCount int64
+	for i, bucket := range buckets {
+		histograms[i] = bucket.Histogram(bounds)
+		for _, count := range histograms[i] {
+			maxCount = max(maxCount, count)
+		}
+	}
+
+	for bin := len(bounds) - 1; bin >= 0; bin-- {
+		var row strings.Builder
+		for i := range buckets {
+			count := histograms[i][bin]
+			if count == 0 {
+				row.WriteString(grayStyle.Render("·"))
+				continue
+			}
+			shade := min(int(float64(count)/float64(maxCount)*float64(len(numericShades))), len(numericShades)-1)
+			row.WriteString(lipgloss.NewStyle().Foreground(ColorGreen).Render(numericShades[shade]))
+		}
+		lines = append(lines, fmt.Sprintf("%*s %s", labelWidth, "≤ "+memory.FormatNumber(bounds[bin]), row.String()))
+	}
+
+	// Percentiles share one scale, so p50 and p99 can be compared
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, bucket := range buckets {
+		if bucket.Count > 0 {
+			low = min(low, bucket.Quantile(0.5))
+			high = max(high, bucket.Quantile(0.99))
+		}
+	}
+	for _, percentile := range []struct {
+		label string
+		q     float64
+		color lipgloss.Color
+	}{{"p50", 0.5, ColorBlue}, {"p99", 0.99, ColorRed}} {
+		var row strings.Builder
+		for _, bucket := range buckets {
+			if bucket.Count == 0 {
+				row.WriteString(" ")
+				continue
+			}
+			row.WriteRune(sparkRune(bucket.Quantile(percentile.q), low, high))
+		}
+		lines = append(lines, fmt.Sprintf("%*s %s", labelWidth, percentile.label, lipgloss.NewStyle().Foreground(percentile.color).Render(row.String())))
+	}
+
+	// Time axis with the first and last column
+	first := start.Format("15:04")
+	last := start.Add(step * time.Duration(len(buckets)-1)).Format("15:04")
+	axis := first
+	if gap := len(buckets) - len(first) - len(last); gap > 0 {
+		axis += strings.Repeat(" ", gap) + last
+	}
+	lines = append(lines, grayStyle.Render(fmt.Sprintf("%*s %s", labelWidth, "", axis)))
+
+	return strings.Join(lines, "\n")
+}
+
+// sparkRune returns the sparkline block for a value between low and high
+func sparkRune(value, low, high float64) rune {
+	if high <= low {
+		return sparkRunes[0]
+	}
+	level := int((value - low) / (high - low) * float64(len(sparkRunes)-1))
+	return sparkRunes[max(0, min(level, len(sparkRunes)-1))]
+}
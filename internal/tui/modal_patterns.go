@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -22,7 +23,12 @@ func (m *DashboardModel) renderPatternsModal() string {
 	m.infoViewport.Height = contentHeight
 
 	// Get pattern content and set it to viewport
-	patternsContent := m.renderAllPatternsContent(contentWidth)
+	var patternsContent string
+	if m.patternDrillDownID != 0 {
+		patternsContent = m.renderPatternDrillDownContent(contentWidth)
+	} else {
+		patternsContent = m.renderAllPatternsContent(contentWidth)
+	}
 	m.infoViewport.SetContent(patternsContent)
 
 	// Create content pane
@@ -44,6 +50,11 @@ func (m *DashboardModel) renderPatternsModal() string {
 	if patternCount > 0 {
 		titleText = fmt.Sprintf("All Log Patterns (%d patterns from %d logs)", patternCount, totalLogs)
 	}
+	statusText := "↑↓: Select • Enter: Filter logs by pattern • PgUp/PgDn: Page • ESC: Close"
+	if m.patternDrillDownID != 0 {
+		titleText = fmt.Sprintf("Pattern #%d Drill-Down", m.patternDrillDownID)
+		statusText = "↑↓/Wheel: Scroll • Backspace: Back to patterns • ESC: Close (filter stays applied)"
+	}
 
 	// Header
 	header := lipgloss.NewStyle().
@@ -55,7 +66,7 @@ func (m *DashboardModel) renderPatternsModal() string {
 	// Status bar
 	statusBar := lipgloss.NewStyle().
 		Foreground(ColorGray).
-		Render("↑↓/Wheel: Scroll • PgUp/PgDn: Page • ESC: Close")
+		Render(statusText)
 
 	// Combine all parts
 	modal := lipgloss.JoinVertical(lipgloss.Left, header, contentPane, statusBar)
@@ -117,10 +128,14 @@ func (m *DashboardModel) renderAllPatternsContent(contentWidth int) string {
 		// Format percentage
 		percentage := fmt.Sprintf("%5.1f%%", pattern.Percentage)
 
-		// Truncate template if needed
+		// Truncate template if needed (leaving room for the new pattern badge)
 		template := pattern.Template
-		if len(template) > templateWidth {
-			template = template[:templateWidth-3] + "..."
+		maxTemplateWidth := templateWidth
+		if pattern.IsNew {
+			maxTemplateWidth -= len(newPatternBadge) + 1
+		}
+		if len(template) > maxTemplateWidth {
+			template = template[:maxTemplateWidth-3] + "..."
 		}
 
 		// Color code based on frequency (high frequency = more important)
@@ -136,15 +151,179 @@ func (m *DashboardModel) renderAllPatternsContent(contentWidth int) string {
 			barColor = lipgloss.NewStyle().Foreground(lipgloss.Color("12"))
 		}
 
-		// Format the line
+		// Format the line, highlighting the selected pattern
+		templateStyle := lipgloss.NewStyle().Foreground(ColorWhite)
+		if i == m.patternsSelected {
+			templateStyle = templateStyle.Background(ColorBlue).Bold(true)
+		}
 		line := fmt.Sprintf("%s %s │ %s",
 			barColor.Render(bar),
 			lipgloss.NewStyle().Foreground(ColorGray).Render(percentage),
-			lipgloss.NewStyle().Foreground(ColorWhite).Render(template),
+			renderNewPatternBadge(pattern)+templateStyle.Render(template),
 		)
 
 		lines = append(lines, line)
 	}
 
 	return strings.Join(lines, "\n")
-}
\ No newline at end of file
+}
+
+// movePatternSelection moves the selected pattern by delta rows and keeps it in view
+func (m *DashboardModel) movePatternSelection(delta int) {
+	if m.drain3Manager == nil {
+		return
+	}
+	count := len(m.drain3Manager.GetTopPatterns(0))
+	if count == 0 {
+		m.patternsSelected = 0
+		return
+	}
+
+	m.patternsSelected = max(0, min(count-1, m.patternsSelected+delta))
+
+	// One pattern per line, so the selection index is also the line number
+	if m.patternsSelected < m.infoViewport.YOffset {
+		m.infoViewport.SetYOffset(m.patternsSelected)
+	} else if m.infoViewport.Height > 0 && m.patternsSelected >= m.infoViewport.YOffset+m.infoViewport.Height {
+		m.infoViewport.SetYOffset(m.patternsSelected - m.infoViewport.Height + 1)
+	}
+}
+
+// drillDownSelectedPattern filters the log view to the selected pattern and
+// switches the modal to its parameter distribution
+func (m *DashboardModel) drillDownSelectedPattern() {
+	if m.drain3Manager == nil {
+		return
+	}
+	patterns := m.drain3Manager.GetTopPatterns(0)
+	if m.patternsSelected < 0 || m.patternsSelected >= len(patterns) {
+		return
+	}
+
+	id := patterns[m.patternsSelected].ID
+	m.filterInput.SetValue(fmt.Sprintf("pattern=%d", id))
+	m.applyFilterInput()
+	m.updateFilteredView()
+
+	m.patternDrillDownID = id
+	m.infoViewport.GotoTop()
+}
+
+// patternParameterStats counts the values seen in each <*> slot of a pattern
+// across the buffered log entries
+func (m *DashboardModel) patternParameterStats(id int64, templateTokens []string) (int, []map[string]int) {
+	slots := 0
+	for _, token := range templateTokens {
+		if token == "<*>" {
+			slots++
+		}
+	}
+
+	distributions := make([]map[string]int, slots)
+	for i := range distributions {
+		distributions[i] = make(map[string]int)
+	}
+
+	matches := 0
+	for _, entry := range m.allLogEntries {
+		if entry.PatternID != id {
+			continue
+		}
+		matches++
+		params := extractPatternParameters(templateTokens, entry.Message)
+		if len(params) != slots {
+			continue
+		}
+		for i, value := range params {
+			distributions[i][value]++
+		}
+	}
+
+	return matches, distributions
+}
+
+// renderPatternDrillDownContent renders the template and parameter value
+// distributions for the drilled-down pattern
+func (m *DashboardModel) renderPatternDrillDownContent(contentWidth int) string {
+	if m.drain3Manager == nil {
+		return helpStyle.Render("Pattern extraction not available")
+	}
+
+	pattern, templateTokens, ok := m.drain3Manager.GetPattern(m.patternDrillDownID)
+	if !ok {
+		return helpStyle.Render("This pattern is no longer tracked (patterns were reset). Press Backspace to return.")
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
+	grayStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	barStyle := lipgloss.NewStyle().Foreground(ColorGreen)
+
+	matches, distributions := m.patternParameterStats(m.patternDrillDownID, templateTokens)
+
+	var lines []string
+	lines = append(lines, labelStyle.Render("Template:"))
+	lines = append(lines, "  "+strings.Join(templateTokens, " "))
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("%s %d total (%.1f%% of logs) • %d in the current buffer",
+		labelStyle.Render("Occurrences:"), pattern.Count, pattern.Percentage, matches))
+	lines = append(lines, grayStyle.Render(fmt.Sprintf("  Log view filtered with: pattern=%d", m.patternDrillDownID)))
+	if pattern.IsNew {
+		lines = append(lines, renderNewPatternBadge(pattern)+"Not seen in previous runs")
+	}
+
+	if len(distributions) == 0 {
+		lines = append(lines, "")
+		lines = append(lines, helpStyle.Render("This pattern has no variable parameters"))
+		return strings.Join(lines, "\n")
+	}
+
+	const maxValues = 10
+	valueWidth := max(contentWidth-32, 16)
+
+	for i, distribution := range distributions {
+		values := make([]PatternCount, 0, len(distribution))
+		total := 0
+		for value, count := range distribution {
+			values = append(values, PatternCount{Pattern: value, Count: count})
+			total += count
+		}
+		sort.Slice(values, func(a, b int) bool {
+			if values[a].Count == values[b].Count {
+				return values[a].Pattern < values[b].Pattern
+			}
+			return values[a].Count > values[b].Count
+		})
+
+		lines = append(lines, "")
+		lines = append(lines, labelStyle.Render(fmt.Sprintf("Parameter %d", i+1))+
+			grayStyle.Render(fmt.Sprintf(" (%d distinct values)", len(values))))
+
+		for j, value := range values {
+			if j >= maxValues {
+				lines = append(lines, grayStyle.Render(fmt.Sprintf("  ... %d more values", len(values)-maxValues)))
+				break
+			}
+
+			barWidth := 12
+			fillWidth := value.Count * barWidth / values[0].Count
+			if fillWidth == 0 {
+				fillWidth = 1
+			}
+			bar := strings.Repeat("█", fillWidth) + strings.Repeat("░", barWidth-fillWidth)
+
+			text := value.Pattern
+			if len(text) > valueWidth {
+				text = text[:valueWidth-3] + "..."
+			}
+
+			lines = append(lines, fmt.Sprintf("  %s %s %s │ %s",
+				barStyle.Render(bar),
+				grayStyle.Render(fmt.Sprintf("%6d", value.Count)),
+				grayStyle.Render(fmt.Sprintf("%5.1f%%", float64(value.Count)*100.0/float64(total))),
+				text,
+			))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
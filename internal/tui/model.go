@@ -27,6 +27,25 @@ const (
 	SectionLogs
 )
 
+// SourceAttribute is the attribute naming the input source (stdin, file, otlp,
+// syslog, vmlogs or k8s) an entry was read from
+const SourceAttribute = "gonzo.source"
+
+// Attributes naming the file an entry was read from, and where in the file
+const (
+	FilePathAttribute   = "log.file.path"
+	FileNameAttribute   = "log.file.name"
+	FileOffsetAttribute = "log.file.offset" // Byte offset of the entry's first line
+)
+
+// Attributes carrying the trace context and instrumentation scope of OTLP records
+const (
+	TraceIDAttribute      = "trace_id"
+	SpanIDAttribute       = "span_id"
+	ScopeNameAttribute    = "otel.scope.name"
+	ScopeVersionAttribute = "otel.scope.version"
+)
+
 // LogEntry represents a formatted log entry
 type LogEntry struct {
 	Timestamp     time.Time // Receive time - when we processed this log
@@ -35,6 +54,7 @@ type LogEntry struct {
 	Message       string
 	RawLine       string
 	Attributes    map[string]string
+	PatternID     int64 // Drain3 pattern the message was clustered into (0 if not yet processed)
 }
 
 // HeatmapMinute represents severity counts for one minute in the heatmap
@@ -69,15 +89,21 @@ type DashboardModel struct {
 	showCountsModal    bool
 	showLogViewerModal    bool
 	showSeverityFilterModal bool
+	showExportModal       bool
 
 	// Data
 	snapshot      *memory.FrequencySnapshot
 	logEntries    []LogEntry       // Filtered view for display
 	allLogEntries []LogEntry       // Complete unfiltered log buffer
 	countsHistory []SeverityCounts // Line counts per interval by severity
+	countsAnomalous []bool         // Whether each countsHistory interval was flagged as anomalous
 
 	// Log Counts Modal Data
 	heatmapData        []HeatmapMinute           // Minute-by-minute severity counts for heatmap (60 minute rolling window)
+	eventHeatmapData   []HeatmapMinute           // Severity counts per bucket of original log time, sorted
+	eventHeatmapStep   time.Duration             // Bucket size of eventHeatmapData, grows with its time range
+	lastEventTime      time.Time                 // Original time of the last entry that had one
+	eventTimeAxis      bool                      // Chart counts by original log time instead of receive time
 	drain3BySeverity   map[string]*Drain3Manager // Separate drain3 instance for each severity
 	servicesBySeverity map[string][]ServiceCount // Top services by severity level
 
@@ -89,7 +115,8 @@ type DashboardModel struct {
 	// Filter
 	filterInput  textinput.Model
 	filterActive bool
-	filterRegex  *regexp.Regexp
+	filterRegex  *regexp.Regexp // Plain regex filter
+	filterQuery  filterNode     // Parsed query expression (takes precedence over filterRegex)
 
 	// Search/Highlight
 	searchInput  textinput.Model
@@ -102,6 +129,39 @@ type DashboardModel struct {
 	severityFilterActive   bool            // Whether severity filtering is active (any severity disabled)
 	severityFilterOriginal map[string]bool // Original state when modal opened (for ESC cancellation)
 
+	// Patterns modal
+	patternsSelected   int   // Selected row in the patterns list
+	patternDrillDownID int64 // Pattern shown in the drill-down view (0 = show the list)
+
+	// New pattern alerting
+	showNewPatterns    bool          // Patterns panel lists new patterns instead of the top patterns
+	newPatternBaseline time.Duration // Learning period before patterns are flagged as new
+
+	// Anomaly detection
+	anomalyDetector *anomalyDetector // Rolling baselines of the interval counts
+
+	// Export modal
+	exportFormatIndex int             // Selected export format (index into exportFormats)
+	exportWindowIndex int             // Selected time window (index into exportWindows)
+	exportFocus       int             // Which export modal field has focus
+	exportPathInput   textinput.Model // Destination file path
+	exportStatus      string          // Last export error, shown in the modal
+
+	// Numeric attributes
+	numericAttributes     map[string]*NumericAttribute // Attributes detected as numeric, by key
+	nonNumericKeys        map[string]bool              // Attributes that turned out not to be numeric
+	showNumericAttributes bool                         // Attributes panel shows numeric attributes instead of the top attributes
+	showNumericModal      bool
+	numericSelected       int           // Selected attribute in the numeric modal
+	numericFilter         numericFilter // Service and severity the numeric modal shows
+
+	// Correlation modal
+	showCorrelationModal bool
+	correlationKeys      []string // Attributes logs can be grouped by
+	correlationKeyIndex  int      // Key currently grouped by (index into correlationKeys)
+	correlationSort      int      // Group order (correlationSortErrors, ...)
+	correlationSelected  int      // Selected group
+
 	// Charts data for rendering
 	chartsInitialized bool
 
@@ -182,6 +242,19 @@ type DashboardModel struct {
 
 	// Version checking
 	versionChecker *versioncheck.Checker // Version checker for update notifications
+
+	// Log view file tags
+	fileColorIndex map[string]int // Color of each file path, in order of appearance
+
+	// Transient status line notice (e.g. a followed file was added)
+	statusNotice     string
+	statusNoticeTime time.Time
+
+	// Replay state shown in the status line (nil unless replaying)
+	replayStatus *ReplayStatusMsg
+
+	// Returns the received spans of a trace (nil unless the OTLP receiver is enabled)
+	traceLookup func(traceID string) []TraceSpan
 }
 
 // UpdateMsg contains data updates for the dashboard
@@ -211,6 +284,31 @@ type AIAnalysisMsg struct {
 // ManualResetMsg represents a manual reset request triggered by user
 type ManualResetMsg struct{}
 
+// StatusNoticeMsg shows a short notice in the status line for a few seconds
+type StatusNoticeMsg string
+
+// statusNoticeDuration is how long a status notice stays visible
+const statusNoticeDuration = 5 * time.Second
+
+// ReplayControlMsg asks the app to pause/resume the replay or move it forward
+type ReplayControlMsg struct {
+	TogglePause bool
+	Seek        time.Duration // Log time to skip
+}
+
+// ReplayStatusMsg reports the replay state for the status line
+type ReplayStatusMsg struct {
+	Speed    string    // Replay speed, e.g. "10x" or "max"
+	Position time.Time // Original time of the last replayed entry
+	Paused   bool
+}
+
+// Log time skipped by the replay seek keys
+const (
+	replaySeekShort = time.Minute
+	replaySeekLong  = 10 * time.Minute
+)
+
 // initializeDrain3BySeverity creates separate drain3 instances for each severity level
 func initializeDrain3BySeverity() map[string]*Drain3Manager {
 	severities := []string{"FATAL", "ERROR", "WARN", "INFO", "DEBUG", "TRACE", "UNKNOWN"}
@@ -233,6 +331,10 @@ func NewDashboardModel(maxLogBuffer int, updateInterval time.Duration, aiModel s
 	searchInput.Placeholder = "Search and highlight text..."
 	searchInput.CharLimit = 200
 
+	exportPathInput := textinput.New()
+	exportPathInput.Placeholder = "Path to export file..."
+	exportPathInput.CharLimit = 500
+
 	chatInput := textarea.New()
 	chatInput.Prompt = "> "
 	chatInput.Placeholder = "Ask a follow-up question about this log..."
@@ -263,10 +365,14 @@ func NewDashboardModel(maxLogBuffer int, updateInterval time.Duration, aiModel s
 
 	m := &DashboardModel{
 		maxLogBuffer:        maxLogBuffer,
+		newPatternBaseline:  DefaultNewPatternBaseline,
+		anomalyDetector:     newAnomalyDetector(DefaultAnomalyThreshold),
 		updateInterval:      updateInterval,
 		reverseScrollWheel:  reverseScrollWheel,
 		filterInput:         filterInput,
 		searchInput:         searchInput,
+		exportPathInput:     exportPathInput,
+		correlationKeys:     DefaultCorrelationKeys,
 		chatInput:           chatInput,
 		selectedIndex:       make(map[Section]int),
 		logEntries:          make([]LogEntry, 0, maxLogBuffer),
@@ -306,6 +412,9 @@ func NewDashboardModel(maxLogBuffer int, updateInterval time.Duration, aiModel s
 		lifetimeAttrCounts:     make(map[string]int64),
 		lifetimeWordCounts:     make(map[string]int64),
 		lifetimeAttrKeyCounts:  make(map[string]map[string]int64),
+		numericAttributes:      make(map[string]*NumericAttribute),
+		nonNumericKeys:         make(map[string]bool),
+		fileColorIndex:         make(map[string]int),
 		stopWords:              stopWords,
 
 		// Initialize severity filter (all levels enabled by default)
@@ -415,6 +524,29 @@ func (m *DashboardModel) getLifetimeAttributeEntries() []*memory.AttributeStatsE
 	return entries
 }
 
+// SetNewPatternBaseline sets how long patterns are learned before new ones are flagged
+func (m *DashboardModel) SetNewPatternBaseline(period time.Duration) {
+	m.newPatternBaseline = period
+	if m.drain3Manager != nil {
+		m.drain3Manager.SetNewPatternBaseline(period)
+	}
+}
+
+// SetAnomalyThreshold sets the z-score above which interval counts are flagged (0 disables detection)
+func (m *DashboardModel) SetAnomalyThreshold(threshold float64) {
+	m.anomalyDetector.threshold = threshold
+}
+
+// SetReplay enables the replay controls, starting from the given replay state
+func (m *DashboardModel) SetReplay(status ReplayStatusMsg) {
+	m.replayStatus = &status
+}
+
+// isNewPatternEntry reports whether the entry's message belongs to a new pattern
+func (m *DashboardModel) isNewPatternEntry(entry LogEntry) bool {
+	return entry.PatternID != 0 && m.drain3Manager != nil && m.drain3Manager.IsNewPattern(entry.PatternID)
+}
+
 // SetVersionChecker sets the version checker for update notifications
 func (m *DashboardModel) SetVersionChecker(checker *versioncheck.Checker) {
 	m.versionChecker = checker
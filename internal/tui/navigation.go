@@ -2,7 +2,6 @@ package tui
 
 import (
 	"fmt"
-	"regexp"
 
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
@@ -22,7 +21,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.filterInput.Blur()
 			// Clear filter and regenerate view
 			m.filterInput.SetValue("")
-			m.filterRegex = nil
+			m.clearFilter()
 			m.updateFilteredView()
 			// Reset to a valid section for navigation
 			if m.activeSection == SectionFilter {
@@ -41,21 +40,11 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		default:
 			// ALL other keys (including 'q') go to filter input
 			var cmd tea.Cmd
+			oldValue := m.filterInput.Value()
 			m.filterInput, cmd = m.filterInput.Update(msg)
 
-			// Update filter regex and regenerate filtered view
-			oldRegex := m.filterRegex
-			if m.filterInput.Value() != "" {
-				if regex, err := regexp.Compile(m.filterInput.Value()); err == nil {
-					m.filterRegex = regex
-				}
-			} else {
-				m.filterRegex = nil
-			}
-
-			// Update filtered view if regex changed
-			if (oldRegex == nil) != (m.filterRegex == nil) ||
-				(oldRegex != nil && m.filterRegex != nil && oldRegex.String() != m.filterRegex.String()) {
+			// Re-parse the filter (query expression or regex) and regenerate filtered view
+			if m.filterInput.Value() != oldValue && m.applyFilterInput() {
 				m.updateFilteredView()
 			}
 
@@ -100,6 +89,106 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	// Correlation modal captures all keys while open
+	if m.showCorrelationModal {
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "escape", "esc", "g":
+			m.showCorrelationModal = false
+		case "up", "k":
+			m.moveCorrelationSelection(-1)
+		case "down", "j":
+			m.moveCorrelationSelection(1)
+		case "pgup":
+			m.moveCorrelationSelection(-max(m.infoViewport.Height/2, 1))
+		case "pgdown":
+			m.moveCorrelationSelection(max(m.infoViewport.Height/2, 1))
+		case "tab":
+			m.correlationKeyIndex = (m.correlationKeyIndex + 1) % len(m.correlationKeys)
+			m.correlationSelected = 0
+			m.infoViewport.GotoTop()
+		case "s":
+			m.correlationSort = (m.correlationSort + 1) % correlationSortOrders
+			m.correlationSelected = 0
+			m.infoViewport.GotoTop()
+		case "enter":
+			m.filterBySelectedCorrelation()
+		}
+		return m, nil
+	}
+
+	// Numeric attributes modal captures all keys while open
+	if m.showNumericModal {
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "escape", "esc":
+			m.showNumericModal = false
+		case "up", "k":
+			m.moveNumericSelection(-1)
+		case "down", "j":
+			m.moveNumericSelection(1)
+		case "s":
+			m.cycleNumericService()
+		case "v":
+			m.cycleNumericSeverity()
+		case "enter":
+			m.filterBySelectedNumeric()
+		}
+		return m, nil
+	}
+
+	// Export modal captures all keys while open (the path field accepts text input)
+	if m.showExportModal {
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "escape", "esc":
+			m.showExportModal = false
+			m.exportPathInput.Blur()
+			return m, nil
+		case "tab", "shift+tab":
+			if msg.String() == "tab" {
+				m.exportFocus = (m.exportFocus + 1) % exportFocusCount
+			} else {
+				m.exportFocus = (m.exportFocus - 1 + exportFocusCount) % exportFocusCount
+			}
+			if m.exportFocus == exportFocusPath {
+				m.exportPathInput.Focus()
+			} else {
+				m.exportPathInput.Blur()
+			}
+			return m, nil
+		case "enter":
+			m.runExport()
+			return m, nil
+		}
+
+		switch m.exportFocus {
+		case exportFocusFormat:
+			switch msg.String() {
+			case "up", "k":
+				m.setExportFormat((m.exportFormatIndex - 1 + len(exportFormats)) % len(exportFormats))
+			case "down", "j":
+				m.setExportFormat((m.exportFormatIndex + 1) % len(exportFormats))
+			}
+		case exportFocusWindow:
+			switch msg.String() {
+			case "up", "k":
+				m.exportWindowIndex = (m.exportWindowIndex - 1 + len(exportWindows)) % len(exportWindows)
+			case "down", "j":
+				m.exportWindowIndex = (m.exportWindowIndex + 1) % len(exportWindows)
+			}
+		case exportFocusPath:
+			var cmd tea.Cmd
+			m.exportPathInput, cmd = m.exportPathInput.Update(msg)
+			m.exportStatus = ""
+			return m, cmd
+		}
+		return m, nil
+	}
+
 	// FIRST PRIORITY: Handle help modal if active
 	if m.showHelp {
 		switch msg.String() {
@@ -207,6 +296,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		if m.showPatternsModal {
 			m.showPatternsModal = false
+			m.patternDrillDownID = 0
 			return m, nil
 		}
 		if m.showStatsModal {
@@ -246,7 +336,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.filterInput.Blur()
 			// Clear filter and regenerate view
 			m.filterInput.SetValue("")
-			m.filterRegex = nil
+			m.clearFilter()
 			m.updateFilteredView()
 			// Reset to a valid section for navigation
 			if m.activeSection == SectionFilter {
@@ -267,7 +357,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		// Clear applied filter/search even when not in input mode
-		if m.filterRegex != nil || m.filterInput.Value() != "" || m.searchTerm != "" || m.searchInput.Value() != "" {
+		if m.hasFilter() || m.filterInput.Value() != "" || m.searchTerm != "" || m.searchInput.Value() != "" {
 			// Clear all filter and search state
 			m.filterActive = false
 			m.searchActive = false
@@ -275,7 +365,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.searchInput.Blur()
 			m.filterInput.SetValue("")
 			m.searchInput.SetValue("")
-			m.filterRegex = nil
+			m.clearFilter()
 			m.searchTerm = ""
 			m.updateFilteredView()
 			// Reset to a valid section for navigation
@@ -298,7 +388,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "/":
 		if !m.showModal && !m.searchActive && !m.showSeverityFilterModal {
 			// Check if filter is already applied (not just active input)
-			if m.filterRegex != nil || m.filterInput.Value() != "" {
+			if m.hasFilter() || m.filterInput.Value() != "" {
 				// Re-enter filter editing mode
 				m.activeSection = SectionFilter
 				m.filterActive = true
@@ -308,7 +398,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.activeSection = SectionFilter
 				m.filterActive = true
 				m.filterInput.SetValue("") // Clear any existing content
-				m.filterRegex = nil        // Clear regex filter
+				m.clearFilter()            // Clear regex/query filter
 				m.updateFilteredView()     // Update view with no filter
 				m.filterInput.Focus()
 			}
@@ -345,12 +435,54 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case "p":
+		// Pause/resume the replay
+		if m.replayStatus != nil && !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
+			return m, func() tea.Msg {
+				return ReplayControlMsg{TogglePause: true}
+			}
+		}
+
+	case "]", "}":
+		// Move the replay forward in log time
+		if m.replayStatus != nil && !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
+			seek := replaySeekShort
+			if msg.String() == "}" {
+				seek = replaySeekLong
+			}
+			return m, func() tea.Msg {
+				return ReplayControlMsg{Seek: seek}
+			}
+		}
+
+	case "t":
+		// Toggle the time axis of the counts chart, heatmap and rates
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
+			m.eventTimeAxis = !m.eventTimeAxis
+			return m, nil
+		}
+
 	case "c":
 		// Toggle Host/Service columns in log view
 		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
 			m.showColumns = !m.showColumns
 			return m, nil
 		}
+
+	case "n":
+		// Toggle the patterns panel between top patterns and new patterns
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
+			m.showNewPatterns = !m.showNewPatterns
+			return m, nil
+		}
+
+	case "a":
+		// Toggle the attributes panel between top attributes and numeric attributes
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
+			m.showNumericAttributes = !m.showNumericAttributes
+			m.selectedIndex[SectionAttributes] = 0
+			return m, nil
+		}
 		
 	case "i":
 		// Toggle statistics modal
@@ -375,6 +507,20 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+	case "g":
+		// Group the buffered logs by trace/request ID
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showHelp && !m.showPatternsModal && !m.showModelSelectionModal && !m.showStatsModal && !m.showCountsModal && !m.showSeverityFilterModal {
+			m.openCorrelationModal()
+			return m, nil
+		}
+
+	case "e":
+		// Export the current filtered view to a file
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showHelp && !m.showPatternsModal && !m.showModelSelectionModal && !m.showStatsModal && !m.showCountsModal && !m.showSeverityFilterModal {
+			m.openExportModal()
+			return m, nil
+		}
+
 	case "m":
 		// Model selection modal
 		if !m.showModal && !m.filterActive && !m.searchActive && !m.showHelp && !m.showPatternsModal && !m.showStatsModal && !m.showSeverityFilterModal {
@@ -414,13 +560,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// If unpausing, process any accumulated logs
 			if wasPaused && !m.viewPaused {
 				// Process unprocessed logs through drain3
-				if m.drain3Manager != nil {
-					// Process all logs that haven't been processed yet
-					for i := m.drain3LastProcessed; i < len(m.allLogEntries); i++ {
-						m.drain3Manager.AddLogMessage(m.allLogEntries[i].Message)
-					}
-					m.drain3LastProcessed = len(m.allLogEntries)
-				}
+				m.processPendingPatterns()
 				
 				// Update the filtered view with all accumulated logs
 				m.updateFilteredView()
@@ -466,7 +606,8 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	// Patterns modal shortcuts
-	if m.showPatternsModal {
+	if m.showPatternsModal && m.patternDrillDownID != 0 {
+		// Drill-down view: scroll the parameter distributions
 		switch msg.String() {
 		case "up", "k":
 			m.infoViewport.ScrollUp(1)
@@ -480,6 +621,34 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case "pgdown":
 			m.infoViewport.HalfPageDown()
 			return m, nil
+		case "backspace", "left":
+			m.patternDrillDownID = 0
+			m.infoViewport.GotoTop()
+			m.movePatternSelection(0)
+			return m, nil
+		case "escape", "esc", "enter":
+			m.showPatternsModal = false
+			m.patternDrillDownID = 0
+			return m, nil
+		}
+	}
+	if m.showPatternsModal {
+		switch msg.String() {
+		case "up", "k":
+			m.movePatternSelection(-1)
+			return m, nil
+		case "down", "j":
+			m.movePatternSelection(1)
+			return m, nil
+		case "pgup":
+			m.movePatternSelection(-max(m.infoViewport.Height/2, 1))
+			return m, nil
+		case "pgdown":
+			m.movePatternSelection(max(m.infoViewport.Height/2, 1))
+			return m, nil
+		case "enter":
+			m.drillDownSelectedPattern()
+			return m, nil
 		case "escape", "esc":
 			m.showPatternsModal = false
 			return m, nil
@@ -871,18 +1040,17 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					}
 					return m, nil
 				}
+			case "t":
+				// Filter the log view by the entry's trace and close the modal
+				if !m.chatActive {
+					if traceID := entryTraceID(*m.currentLogEntry); traceID != "" {
+						m.filterByTrace(traceID)
+						m.closeLogDetails()
+						return m, nil
+					}
+				}
 			case "escape", "esc": // escape to close modal (only if not in chat mode)
-				m.showModal = false
-				m.modalContent = ""
-				m.currentLogEntry = nil // Clear current log entry when closing modal
-				// Reset viewport scroll position for next modal
-				m.infoViewport.GotoTop()
-				m.chatViewport.GotoTop()
-				m.aiAnalysisResult = ""
-				m.chatHistory = []string{}
-				m.chatActive = false
-				m.chatAiAnalyzing = false  // Reset chat AI state
-				m.chatInput.SetValue("")
+				m.closeLogDetails()
 				return m, nil
 			}
 
@@ -1137,6 +1305,9 @@ func (m *DashboardModel) moveSelection(delta int) {
 		// Limit to 10 visible items in attributes chart - use lifetime data
 		lifetimeAttrs := m.getLifetimeAttributeEntries()
 		maxItems = min(len(lifetimeAttrs), 10)
+		if m.showNumericAttributes {
+			maxItems = min(len(m.getNumericAttributes()), 9) // Below the column header
+		}
 	case SectionDistribution:
 		maxItems = 7 // Fixed number of distribution ranges
 	case SectionCounts:
@@ -1217,6 +1388,15 @@ func (m *DashboardModel) showDetails() (tea.Model, tea.Cmd) {
 		}
 
 	case SectionAttributes:
+		// Numeric attributes open their percentiles and histograms
+		if m.showNumericAttributes {
+			if selectedIdx < len(m.getNumericAttributes()) {
+				m.openNumericModal(selectedIdx)
+				m.currentLogEntry = nil
+			}
+			return m, nil
+		}
+
 		lifetimeAttrs := m.getLifetimeAttributeEntries()
 		if selectedIdx < len(lifetimeAttrs) {
 			entry := lifetimeAttrs[selectedIdx]
@@ -1234,6 +1414,9 @@ func (m *DashboardModel) showDetails() (tea.Model, tea.Cmd) {
 		// Show patterns modal with all patterns
 		if m.drain3Manager != nil {
 			m.showPatternsModal = true
+			m.patternsSelected = 0
+			m.patternDrillDownID = 0
+			m.infoViewport.GotoTop()
 			// Clear log entry to ensure single modal layout for patterns
 			m.currentLogEntry = nil
 			return m, nil
@@ -1254,3 +1437,18 @@ func (m *DashboardModel) showDetails() (tea.Model, tea.Cmd) {
 
 	return m, nil
 }
+
+// closeLogDetails closes the log details modal and resets its state
+func (m *DashboardModel) closeLogDetails() {
+	m.showModal = false
+	m.modalContent = ""
+	m.currentLogEntry = nil // Clear current log entry when closing modal
+	// Reset viewport scroll position for next modal
+	m.infoViewport.GotoTop()
+	m.chatViewport.GotoTop()
+	m.aiAnalysisResult = ""
+	m.chatHistory = []string{}
+	m.chatActive = false
+	m.chatAiAnalyzing = false // Reset chat AI state
+	m.chatInput.SetValue("")
+}
@@ -0,0 +1,270 @@
+package tui
+
+import (
+	"sort"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/memory"
+)
+
+// numericRetention is how much log time the per-minute values of numeric
+// attributes are kept for, counted back from the newest minute
+const numericRetention = 60 * time.Minute
+
+// maxNumericAttributes limits how many attributes are tracked as numeric
+const maxNumericAttributes = 32
+
+// numericGiveUpCount is the number of non-numeric values after which an
+// attribute that is mostly not numeric stops being tracked
+const numericGiveUpCount = 10
+
+// NumericSeries is the distribution of a numeric attribute for one service and severity
+type NumericSeries struct {
+	Service  string         `json:"service"`
+	Severity string         `json:"severity"`
+	Values   *memory.Sketch `json:"values"`
+}
+
+// NumericMinute holds the values of a numeric attribute logged in one minute
+type NumericMinute struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Series    []NumericSeries `json:"series"`
+}
+
+// NumericAttribute tracks the values of an attribute that holds numbers, like
+// duration_ms, status or bytes
+type NumericAttribute struct {
+	Key     string          `json:"key"`
+	Values  *memory.Sketch  `json:"values"`  // All values ever seen
+	Other   int64           `json:"other"`   // Values that were not numbers
+	Minutes []NumericMinute `json:"minutes"` // Recent values per minute of log time, oldest first
+}
+
+// isNumeric reports whether enough of the attribute's values were numbers to show it
+func (a *NumericAttribute) isNumeric() bool {
+	return memory.IsNumeric(a.Values.Count, a.Values.Count+a.Other)
+}
+
+// numericFilter selects the values of a service and severity ("" for all)
+type numericFilter struct {
+	service  string
+	severity string
+}
+
+// matches reports whether a series passes the filter
+func (f numericFilter) matches(series NumericSeries) bool {
+	return (f.service == "" || series.Service == f.service) &&
+		(f.severity == "" || series.Severity == f.severity)
+}
+
+// updateNumericAttributes adds the numeric attribute values of an entry to
+// their sketches. Attributes are tracked from their first numeric value and
+// dropped again once they turn out to be mostly text. New attributes are
+// admitted in key order, so the same logs always track the same attributes.
+func (m *DashboardModel) updateNumericAttributes(entry LogEntry) {
+	var newKeys []string
+	for key, value := range entry.Attributes {
+		if key == FileOffsetAttribute || m.nonNumericKeys[key] {
+			continue
+		}
+		if attr := m.numericAttributes[key]; attr != nil {
+			m.addNumericValue(attr, value, entry)
+		} else if _, ok := memory.ParseNumeric(value); ok {
+			newKeys = append(newKeys, key)
+		}
+	}
+
+	sort.Strings(newKeys)
+	for _, key := range newKeys {
+		if len(m.numericAttributes) >= maxNumericAttributes {
+			break
+		}
+		attr := &NumericAttribute{Key: key, Values: memory.NewSketch()}
+		m.numericAttributes[key] = attr
+		m.addNumericValue(attr, entry.Attributes[key], entry)
+	}
+}
+
+// addNumericValue adds an attribute value of an entry, counting values that
+// are not numbers until the attribute is given up
+func (m *DashboardModel) addNumericValue(attr *NumericAttribute, value string, entry LogEntry) {
+	number, ok := memory.ParseNumeric(value)
+	if !ok {
+		attr.Other++
+		if attr.Other >= numericGiveUpCount && !attr.isNumeric() {
+			delete(m.numericAttributes, attr.Key)
+			m.nonNumericKeys[attr.Key] = true
+		}
+		return
+	}
+
+	attr.Values.Add(number)
+	attr.addToMinute(entryTime(entry).Truncate(time.Minute), GetServiceName(entry), normalizeSeverityLevel(entry.Severity), number)
+}
+
+// addToMinute adds a value to the series of its minute. The minutes are kept
+// sorted, so out-of-order entries land where they belong.
+func (a *NumericAttribute) addToMinute(minute time.Time, service, severity string, value float64) {
+	if n := len(a.Minutes); n > 0 && !minute.After(a.Minutes[n-1].Timestamp.Add(-numericRetention)) {
+		return // Too late for the retained range, only counted in the lifetime sketch
+	}
+
+	i := sort.Search(len(a.Minutes), func(i int) bool {
+		return !a.Minutes[i].Timestamp.Before(minute)
+	})
+	if i == len(a.Minutes) || !a.Minutes[i].Timestamp.Equal(minute) {
+		a.Minutes = append(a.Minutes, NumericMinute{})
+		copy(a.Minutes[i+1:], a.Minutes[i:])
+		a.Minutes[i] = NumericMinute{Timestamp: minute}
+	}
+
+	target := &a.Minutes[i]
+	found := false
+	for j := range target.Series {
+		if target.Series[j].Service == service && target.Series[j].Severity == severity {
+			target.Series[j].Values.Add(value)
+			found = true
+			break
+		}
+	}
+	if !found {
+		sketch := memory.NewSketch()
+		sketch.Add(value)
+		target.Series = append(target.Series, NumericSeries{Service: service, Severity: severity, Values: sketch})
+	}
+
+	// Drop minutes that fell out of the retained range
+	cutoff := a.Minutes[len(a.Minutes)-1].Timestamp.Add(-numericRetention)
+	drop := 0
+	for drop < len(a.Minutes) && !a.Minutes[drop].Timestamp.After(cutoff) {
+		drop++
+	}
+	if drop > 0 {
+		a.Minutes = append(a.Minutes[:0], a.Minutes[drop:]...)
+	}
+}
+
+// window merges the retained values that pass the filter
+func (a *NumericAttribute) window(filter numericFilter) *memory.Sketch {
+	merged := memory.NewSketch()
+	for _, minute := range a.Minutes {
+		for _, series := range minute.Series {
+			if filter.matches(series) {
+				merged.Merge(series.Values)
+			}
+		}
+	}
+	return merged
+}
+
+// buckets merges the retained values that pass the filter into at most n
+// buckets of the smallest step from eventTimeSteps that covers them. The
+// buckets end with the newest minute; empty buckets are included.
+func (a *NumericAttribute) buckets(filter numericFilter, n int) ([]*memory.Sketch, time.Time, time.Duration) {
+	if len(a.Minutes) == 0 || n <= 0 {
+		return nil, time.Time{}, time.Minute
+	}
+
+	first := a.Minutes[0].Timestamp
+	last := a.Minutes[len(a.Minutes)-1].Timestamp
+	step := eventTimeSteps[len(eventTimeSteps)-1]
+	for _, candidate := range eventTimeSteps {
+		if int(last.Sub(first)/candidate)+1 <= n {
+			step = candidate
+			break
+		}
+	}
+
+	end := last.Truncate(step)
+	count := min(int(end.Sub(first.Truncate(step))/step)+1, n)
+	start := end.Add(-time.Duration(count-1) * step)
+
+	buckets := make([]*memory.Sketch, count)
+	for i := range buckets {
+		buckets[i] = memory.NewSketch()
+	}
+	for _, minute := range a.Minutes {
+		i := int(minute.Timestamp.Truncate(step).Sub(start) / step)
+		if i < 0 || i >= count {
+			continue
+		}
+		for _, series := range minute.Series {
+			if filter.matches(series) {
+				buckets[i].Merge(series.Values)
+			}
+		}
+	}
+	return buckets, start, step
+}
+
+// services returns the services that logged values of the attribute, sorted
+func (a *NumericAttribute) services() []string {
+	seen := make(map[string]bool)
+	var services []string
+	for _, minute := range a.Minutes {
+		for _, series := range minute.Series {
+			if !seen[series.Service] {
+				seen[series.Service] = true
+				services = append(services, series.Service)
+			}
+		}
+	}
+	sort.Strings(services)
+	return services
+}
+
+// severities returns the severities that logged values of the attribute, most severe first
+func (a *NumericAttribute) severities() []string {
+	seen := make(map[string]bool)
+	var severities []string
+	for _, minute := range a.Minutes {
+		for _, series := range minute.Series {
+			if !seen[series.Severity] {
+				seen[series.Severity] = true
+				severities = append(severities, series.Severity)
+			}
+		}
+	}
+	sort.Slice(severities, func(i, j int) bool {
+		if severityRank[severities[i]] != severityRank[severities[j]] {
+			return severityRank[severities[i]] > severityRank[severities[j]]
+		}
+		return severities[i] < severities[j]
+	})
+	return severities
+}
+
+// getNumericAttributes returns the attributes detected as numeric, most values first
+func (m *DashboardModel) getNumericAttributes() []*NumericAttribute {
+	attributes := make([]*NumericAttribute, 0, len(m.numericAttributes))
+	for _, attr := range m.numericAttributes {
+		if attr.isNumeric() {
+			attributes = append(attributes, attr)
+		}
+	}
+
+	sort.Slice(attributes, func(i, j int) bool {
+		if attributes[i].Values.Count == attributes[j].Values.Count {
+			return attributes[i].Key < attributes[j].Key
+		}
+		return attributes[i].Values.Count > attributes[j].Values.Count
+	})
+	return attributes
+}
+
+// nextFilterValue returns the value after current in values, cycling through
+// "" (all values) first
+func nextFilterValue(values []string, current string) string {
+	for i, value := range values {
+		if value == current {
+			if i+1 < len(values) {
+				return values[i+1]
+			}
+			return ""
+		}
+	}
+	if len(values) > 0 && current == "" {
+		return values[0]
+	}
+	return ""
+}
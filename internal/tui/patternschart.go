@@ -7,6 +7,17 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// newPatternBadge marks patterns first seen after the baseline
+const newPatternBadge = "NEW"
+
+// renderNewPatternBadge returns the styled badge (with trailing space) for new patterns
+func renderNewPatternBadge(pattern PatternInfo) string {
+	if !pattern.IsNew {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(ColorYellow).Bold(true).Render(newPatternBadge) + " "
+}
+
 // renderDrain3Chart renders the drain3 pattern extraction chart
 func (m *DashboardModel) renderDrain3Chart(width, height int) string {
 	// Use MaxHeight instead of Height to prevent empty space
@@ -21,12 +32,27 @@ func (m *DashboardModel) renderDrain3Chart(width, height int) string {
 		patternCount, totalLogs = m.drain3Manager.GetStats()
 	}
 
+	var newPatterns []NewPatternInfo
+	if m.drain3Manager != nil {
+		newPatterns = m.drain3Manager.GetNewPatterns()
+	}
+
+	// New patterns view replaces the top patterns when toggled with 'n'
+	if m.showNewPatterns {
+		titleText := fmt.Sprintf("New Patterns (%d since baseline)", len(newPatterns))
+		title := chartTitleStyle.Render(titleText)
+		return style.Render(lipgloss.JoinVertical(lipgloss.Left, title, m.renderNewPatternsContent(newPatterns, width)))
+	}
+
 	// Build title with stats
 	titleText := "Log Patterns"
 	if patternCount > 0 {
 		titleText = fmt.Sprintf("Log Patterns (%d patterns from %d logs)", patternCount, totalLogs)
 	}
 	title := chartTitleStyle.Render(titleText)
+	if len(newPatterns) > 0 {
+		title += lipgloss.NewStyle().Foreground(ColorYellow).Bold(true).Render(fmt.Sprintf(" %d new (n)", len(newPatterns)))
+	}
 
 	var content string
 	if m.drain3Manager != nil && patternCount > 0 {
@@ -87,10 +113,14 @@ func (m *DashboardModel) renderDrain3Content(chartWidth int) string {
 			// Format percentage
 			percentage := fmt.Sprintf("%5.1f%%", pattern.Percentage)
 
-			// Truncate template if needed
+			// Truncate template if needed (leaving room for the new pattern badge)
 			template := pattern.Template
-			if len(template) > templateWidth {
-				template = template[:templateWidth-3] + "..."
+			maxTemplateWidth := templateWidth
+			if pattern.IsNew {
+				maxTemplateWidth -= len(newPatternBadge) + 1
+			}
+			if len(template) > maxTemplateWidth {
+				template = template[:maxTemplateWidth-3] + "..."
 			}
 
 			// Color code based on frequency (high frequency = more important)
@@ -110,7 +140,7 @@ func (m *DashboardModel) renderDrain3Content(chartWidth int) string {
 			line := fmt.Sprintf("%s %s │ %s",
 				barColor.Render(bar),
 				lipgloss.NewStyle().Foreground(ColorGray).Render(percentage),
-				lipgloss.NewStyle().Foreground(ColorWhite).Render(template),
+				renderNewPatternBadge(pattern)+lipgloss.NewStyle().Foreground(ColorWhite).Render(template),
 			)
 
 			lines = append(lines, line)
@@ -129,3 +159,49 @@ func (m *DashboardModel) renderDrain3Content(chartWidth int) string {
 
 	return strings.Join(lines, "\n")
 }
+
+
+// renderNewPatternsContent renders the most recent new patterns with their
+// first-seen time and count
+func (m *DashboardModel) renderNewPatternsContent(patterns []NewPatternInfo, chartWidth int) string {
+	// Keep the same height as the top patterns view
+	const displayLines = 8
+
+	grayStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	if len(patterns) == 0 {
+		lines := []string{helpStyle.Render("No new patterns since the baseline")}
+		for len(lines) < displayLines {
+			lines = append(lines, "")
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	// Format: first seen(8) + count(7) + separators(5) = 20
+	templateWidth := chartWidth - 24
+	if templateWidth < 20 {
+		templateWidth = 20
+	}
+
+	timeStyle := lipgloss.NewStyle().Foreground(ColorYellow).Bold(true)
+	var lines []string
+	for i := 0; i < displayLines; i++ {
+		if i >= len(patterns) {
+			lines = append(lines, "")
+			continue
+		}
+		pattern := patterns[i]
+
+		template := pattern.Template
+		if len(template) > templateWidth {
+			template = template[:templateWidth-3] + "..."
+		}
+
+		lines = append(lines, fmt.Sprintf("%s %s │ %s",
+			timeStyle.Render(pattern.FirstSeen.Format("15:04:05")),
+			grayStyle.Render(fmt.Sprintf("%7d", pattern.Count)),
+			lipgloss.NewStyle().Foreground(ColorWhite).Render(template),
+		))
+	}
+
+	return strings.Join(lines, "\n")
+}
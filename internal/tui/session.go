@@ -0,0 +1,267 @@
+package tui
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/memory"
+)
+
+// SessionVersion is bumped whenever the on-disk session layout changes
+const SessionVersion = 3
+
+// Session is a snapshot of the dashboard state that can be saved and reopened later
+type Session struct {
+	Version int       `json:"version"`
+	SavedAt time.Time `json:"saved_at"`
+
+	// Log data
+	LogEntries         []LogEntry                `json:"log_entries"`
+	CountsHistory      []SeverityCounts          `json:"counts_history"`
+	HeatmapData        []HeatmapMinute           `json:"heatmap_data"`
+	EventHeatmapData   []HeatmapMinute           `json:"event_heatmap_data,omitempty"`
+	EventHeatmapStep   time.Duration             `json:"event_heatmap_step,omitempty"` // Bucket size of EventHeatmapData, a minute if unset
+	ServicesBySeverity map[string][]ServiceCount `json:"services_by_severity"`
+	Frequency          *memory.FrequencySnapshot `json:"frequency,omitempty"`
+
+	// Drain3 pattern state
+	Drain3           *Drain3Manager            `json:"drain3,omitempty"`
+	Drain3BySeverity map[string]*Drain3Manager `json:"drain3_by_severity,omitempty"`
+
+	// Statistics
+	Stats             SessionStats                 `json:"stats"`
+	NumericAttributes map[string]*NumericAttribute `json:"numeric_attributes,omitempty"`
+	NonNumericKeys    []string                     `json:"non_numeric_keys,omitempty"` // Attributes given up as mostly text
+	Anomalies         []Anomaly                    `json:"anomalies,omitempty"`
+
+	// Investigation state
+	ChatHistory    []string        `json:"chat_history"`
+	Filter         string          `json:"filter,omitempty"`
+	SearchTerm     string          `json:"search_term,omitempty"`
+	SeverityFilter map[string]bool `json:"severity_filter"`
+}
+
+// SessionStats holds the lifetime statistics of a session
+type SessionStats struct {
+	StartTime      time.Time                   `json:"start_time"`
+	TotalBytes     int64                       `json:"total_bytes"`
+	PeakLogsPerSec float64                     `json:"peak_logs_per_sec"`
+	TotalLogsEver  int                         `json:"total_logs_ever"`
+	SeverityCounts map[string]int64            `json:"severity_counts"`
+	HostCounts     map[string]int64            `json:"host_counts"`
+	ServiceCounts  map[string]int64            `json:"service_counts"`
+	AttrCounts     map[string]int64            `json:"attr_counts"`
+	WordCounts     map[string]int64            `json:"word_counts"`
+	AttrKeyCounts  map[string]map[string]int64 `json:"attr_key_counts"`
+}
+
+// Session captures the current dashboard state
+func (m *DashboardModel) Session() *Session {
+	return &Session{
+		Version:            SessionVersion,
+		SavedAt:            time.Now(),
+		LogEntries:         m.allLogEntries,
+		CountsHistory:      m.countsHistory,
+		HeatmapData:        m.heatmapData,
+		EventHeatmapData:   m.eventHeatmapData,
+		EventHeatmapStep:   m.eventHeatmapStep,
+		ServicesBySeverity: m.servicesBySeverity,
+		Frequency:          m.snapshot,
+		Drain3:             m.drain3Manager,
+		Drain3BySeverity:   m.drain3BySeverity,
+		Stats: SessionStats{
+			StartTime:      m.statsStartTime,
+			TotalBytes:     m.statsTotalBytes,
+			PeakLogsPerSec: m.statsPeakLogsPerSec,
+			TotalLogsEver:  m.statsTotalLogsEver,
+			SeverityCounts: m.lifetimeSeverityCounts,
+			HostCounts:     m.lifetimeHostCounts,
+			ServiceCounts:  m.lifetimeServiceCounts,
+			AttrCounts:     m.lifetimeAttrCounts,
+			WordCounts:     m.lifetimeWordCounts,
+			AttrKeyCounts:  m.lifetimeAttrKeyCounts,
+		},
+		NumericAttributes: m.numericAttributes,
+		NonNumericKeys:    slices.Sorted(maps.Keys(m.nonNumericKeys)),
+		Anomalies:         m.anomalyDetector.anomalies,
+		ChatHistory:       m.chatHistory,
+		Filter:            m.filterInput.Value(),
+		SearchTerm:        m.searchTerm,
+		SeverityFilter:    m.severityFilter,
+	}
+}
+
+// RestoreSession replaces the dashboard state with a previously saved session
+func (m *DashboardModel) RestoreSession(s *Session) {
+	if s == nil {
+		return
+	}
+
+	m.allLogEntries = append(make([]LogEntry, 0, max(m.maxLogBuffer, len(s.LogEntries))), s.LogEntries...)
+	if len(m.allLogEntries) > m.maxLogBuffer {
+		m.allLogEntries = m.allLogEntries[len(m.allLogEntries)-m.maxLogBuffer:]
+	}
+	for _, entry := range m.allLogEntries {
+		m.assignFileColor(entry)
+	}
+	if s.CountsHistory != nil {
+		m.countsHistory = s.CountsHistory
+		m.countsAnomalous = nil // Interval flags are not saved, anomalies below keep the details
+	}
+	if s.HeatmapData != nil {
+		m.heatmapData = s.HeatmapData
+	}
+	if s.EventHeatmapData != nil {
+		m.eventHeatmapData = s.EventHeatmapData
+		m.eventHeatmapStep = s.EventHeatmapStep
+	}
+	if s.ServicesBySeverity != nil {
+		m.servicesBySeverity = s.ServicesBySeverity
+	}
+	if s.Frequency != nil {
+		m.snapshot = s.Frequency
+	}
+
+	// Drain3 state (keep fresh instances for any severity missing from the session)
+	if s.Drain3 != nil {
+		m.drain3Manager = s.Drain3
+		m.drain3Manager.SetNewPatternBaseline(m.newPatternBaseline)
+	}
+	for severity, manager := range s.Drain3BySeverity {
+		if manager != nil {
+			m.drain3BySeverity[severity] = manager
+		}
+	}
+	m.drain3LastProcessed = len(m.allLogEntries)
+
+	// Statistics
+	m.statsStartTime = s.Stats.StartTime
+	m.statsTotalBytes = s.Stats.TotalBytes
+	m.statsPeakLogsPerSec = s.Stats.PeakLogsPerSec
+	m.statsTotalLogsEver = s.Stats.TotalLogsEver
+	restoreCounts(&m.lifetimeSeverityCounts, s.Stats.SeverityCounts)
+	restoreCounts(&m.lifetimeHostCounts, s.Stats.HostCounts)
+	restoreCounts(&m.lifetimeServiceCounts, s.Stats.ServiceCounts)
+	restoreCounts(&m.lifetimeAttrCounts, s.Stats.AttrCounts)
+	restoreCounts(&m.lifetimeWordCounts, s.Stats.WordCounts)
+	if s.Stats.AttrKeyCounts != nil {
+		m.lifetimeAttrKeyCounts = s.Stats.AttrKeyCounts
+	}
+
+	if s.NumericAttributes != nil {
+		m.numericAttributes = s.NumericAttributes
+	}
+	m.nonNumericKeys = make(map[string]bool, len(s.NonNumericKeys))
+	for _, key := range s.NonNumericKeys {
+		m.nonNumericKeys[key] = true
+	}
+
+	m.anomalyDetector.anomalies = s.Anomalies
+
+	// Investigation state
+	if s.ChatHistory != nil {
+		m.chatHistory = s.ChatHistory
+	}
+	for severity, enabled := range s.SeverityFilter {
+		m.severityFilter[severity] = enabled
+	}
+	m.updateSeverityFilterActiveStatus()
+
+	m.searchTerm = s.SearchTerm
+	m.searchInput.SetValue(s.SearchTerm)
+	m.filterInput.SetValue(s.Filter)
+	m.applyFilterInput()
+	m.updateFilteredView()
+}
+
+// LoadPatternsState restores learned log templates from a previous run (see --patterns-state)
+func (m *DashboardModel) LoadPatternsState(path string) error {
+	if m.drain3Manager == nil {
+		return nil
+	}
+	return m.drain3Manager.LoadPatternsState(path)
+}
+
+// SavePatternsState writes the learned log templates so the next run can reuse them
+func (m *DashboardModel) SavePatternsState(path string) error {
+	if m.drain3Manager == nil {
+		return nil
+	}
+	return m.drain3Manager.SavePatternsState(path)
+}
+
+// PatternsBaselineSize returns the number of templates loaded from a previous run
+func (m *DashboardModel) PatternsBaselineSize() int {
+	if m.drain3Manager == nil {
+		return 0
+	}
+	return m.drain3Manager.BaselineSize()
+}
+
+func restoreCounts(target *map[string]int64, counts map[string]int64) {
+	if counts != nil {
+		*target = counts
+	}
+}
+
+// SaveSession writes a session to path as gzip-compressed JSON
+func SaveSession(path string, s *Session) error {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	// Write to a temporary file first so a failed save never clobbers an existing session
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(file)
+	err = json.NewEncoder(gz).Encode(s)
+	if closeErr := gz.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// LoadSession reads a session written by SaveSession
+func LoadSession(path string) (*Session, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("not a gonzo session file: %w", err)
+	}
+	defer gz.Close()
+
+	var s Session
+	if err := json.NewDecoder(gz).Decode(&s); err != nil {
+		return nil, fmt.Errorf("failed to decode session: %w", err)
+	}
+	if s.Version > SessionVersion {
+		return nil, fmt.Errorf("session version %d is newer than supported version %d", s.Version, SessionVersion)
+	}
+
+	return &s, nil
+}
@@ -41,6 +41,28 @@ func (sc *SeverityCounts) AddCount(severity string) {
 	sc.Total++
 }
 
+// Count returns the count for a severity level (FATAL includes CRITICAL)
+func (sc SeverityCounts) Count(severity string) int {
+	switch severity {
+	case "TRACE":
+		return sc.Trace
+	case "DEBUG":
+		return sc.Debug
+	case "INFO":
+		return sc.Info
+	case "WARN":
+		return sc.Warn
+	case "ERROR":
+		return sc.Error
+	case "FATAL":
+		return sc.Fatal + sc.Critical
+	case "CRITICAL":
+		return sc.Critical
+	default:
+		return sc.Unknown
+	}
+}
+
 // normalizeSeverityLevel normalizes severity levels to standard format
 func normalizeSeverityLevel(severity string) string {
 	normalized := strings.ToUpper(strings.TrimSpace(severity))
@@ -27,7 +27,7 @@ func (m *DashboardModel) renderStatsContent(contentWidth int) string {
 	halfWidth := (contentWidth - 3) / 2 // -3 for spacing between columns
 
 	// Row 1: General Statistics | Severity Distribution (side by side)
-	generalStats := m.renderStatsSection("General Statistics", []StatItem{
+	generalItems := []StatItem{
 		{"Total Logs Processed", fmt.Sprintf("%d", m.statsTotalLogsEver)},
 		{"Logs in Buffer", fmt.Sprintf("%d", len(m.allLogEntries))},
 		{"Filtered Logs Displayed", fmt.Sprintf("%d", len(m.logEntries))},
@@ -35,7 +35,12 @@ func (m *DashboardModel) renderStatsContent(contentWidth int) string {
 		{"Uptime", m.formatUptime()},
 		{"Current Processing Rate", m.formatCurrentRate()},
 		{"Peak Logs per Second", fmt.Sprintf("%.1f", m.statsPeakLogsPerSec)},
-	}, halfWidth)
+	}
+	if m.eventTimeAxis {
+		// Rates by original log time, meaningful for files read at once
+		generalItems = append(generalItems, m.eventTimeStats()...)
+	}
+	generalStats := m.renderStatsSection("General Statistics", generalItems, halfWidth)
 
 	// Severity Statistics with visual bar chart
 	severityStats := m.calculateSeverityStats()
@@ -45,12 +50,23 @@ func (m *DashboardModel) renderStatsContent(contentWidth int) string {
 	row1 := m.combineSideBySide(generalStats, severitySection)
 	sections = append(sections, row1)
 
+	// Anomaly Section (rate spikes against the learned baselines)
+	if anomalies := m.anomalyDetector.recentAnomalies(); len(anomalies) > 0 {
+		sections = append(sections, m.renderAnomalySection(anomalies[:min(10, len(anomalies))], contentWidth))
+	}
+
 	// Host Statistics Section
 	hostStats := m.calculateHostStats()
 	if len(hostStats) > 0 {
 		sections = append(sections, m.renderStatsSection("Top Hosts", hostStats[:min(10, len(hostStats))], contentWidth))
 	}
 
+	// Source Statistics Section (only interesting when several sources are combined)
+	sourceStats := m.calculateSourceStats()
+	if len(sourceStats) > 1 {
+		sections = append(sections, m.renderStatsSection("Top Sources", sourceStats, contentWidth))
+	}
+
 	// Row 2: Top Services | Pattern Analysis (side by side)
 	serviceStats := m.calculateServiceStats()
 	var row2 string
@@ -174,6 +190,16 @@ func (m *DashboardModel) calculateHostStats() []StatItem {
 	return m.sortAndFormatStats(hostCounts)
 }
 
+func (m *DashboardModel) calculateSourceStats() []StatItem {
+	// Sources are tagged as an attribute, so use its lifetime value counts
+	sourceCounts := make(map[string]int)
+	for source, count := range m.lifetimeAttrKeyCounts[SourceAttribute] {
+		sourceCounts[source] = int(count)
+	}
+
+	return m.sortAndFormatStats(sourceCounts)
+}
+
 func (m *DashboardModel) calculateServiceStats() []StatItem {
 	// Use lifetime service counts directly
 	serviceCounts := make(map[string]int)
@@ -539,3 +565,57 @@ func (m *DashboardModel) renderAttributeSection(stats []AttributeStatFormatted,
 		Width(width).
 		Render(sectionContent)
 }
+
+// renderAnomalySection renders the detected rate anomalies with their top contributing patterns
+func (m *DashboardModel) renderAnomalySection(anomalies []Anomaly, width int) string {
+	titleContent := chartTitleStyle.Render("Anomalies")
+
+	var contentLines []string
+	availableWidth := width - 4 // Account for section borders and padding
+	timeStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	detailStyle := lipgloss.NewStyle().Foreground(ColorWhite)
+	patternStyle := lipgloss.NewStyle().Foreground(ColorGray)
+
+	// Severity and service form one column, padded to the widest entry
+	seriesNames := make([]string, len(anomalies))
+	seriesWidth := 0
+	for i, anomaly := range anomalies {
+		seriesNames[i] = anomaly.Severity
+		if anomaly.Service != "" {
+			seriesNames[i] += " " + anomaly.Service
+		}
+		seriesWidth = max(seriesWidth, len(seriesNames[i]))
+	}
+
+	for i, anomaly := range anomalies {
+		series := fmt.Sprintf("%-*s", seriesWidth, seriesNames[i])
+		detail := fmt.Sprintf("%d logs/interval (expected %.1f, z=%.1f)", anomaly.Count, anomaly.Expected, anomaly.ZScore)
+		if anomaly.Intervals > 1 {
+			detail += fmt.Sprintf(" for %d intervals", anomaly.Intervals)
+		}
+
+		severityStyle := lipgloss.NewStyle().Foreground(getSeverityColor(anomaly.Severity)).Bold(true)
+		line := fmt.Sprintf("%s  %s  %s",
+			timeStyle.Render(anomaly.Start.Format("15:04:05")),
+			severityStyle.Render(series),
+			detailStyle.Render(detail))
+		contentLines = append(contentLines, line)
+
+		for _, pattern := range anomaly.Patterns {
+			text := fmt.Sprintf("    %6d  %s", pattern.Count, pattern.Pattern)
+			if len(text) > availableWidth && availableWidth > 3 {
+				text = text[:availableWidth-3] + "..."
+			}
+			contentLines = append(contentLines, patternStyle.Render(text))
+		}
+	}
+
+	content := strings.Join(contentLines, "\n")
+
+	// Use sectionStyle for consistent section formatting with borders
+	sectionContent := lipgloss.JoinVertical(lipgloss.Left, titleContent, content)
+
+	return sectionStyle.
+		Width(width).
+		Render(sectionContent)
+}
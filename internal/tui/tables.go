@@ -160,6 +160,12 @@ func (m *DashboardModel) formatLogDetails(entry LogEntry, maxWidth int) string {
 		details.WriteString(m.formatAttributesTable(entry.Attributes, maxWidth))
 	}
 
+	// Trace section: the span tree and the other logs of the entry's trace
+	if traceID := entryTraceID(entry); traceID != "" {
+		details.WriteString("\n" + headerStyle.Render("Trace") + "\n")
+		details.WriteString(m.formatTraceDetails(entry, traceID, maxWidth))
+	}
+
 	// AI Analysis section
 	if m.aiAnalysisResult != "" && m.aiAnalysisResult != "Analyzing..." {
 		details.WriteString("\n" + headerStyle.Render("🤖 AI Analysis") + "\n")
@@ -0,0 +1,190 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxTraceLogs is the number of logs of a trace listed in the log details
+const maxTraceLogs = 20
+
+// TraceSpan is a received span of a trace, shown in the log details
+type TraceSpan struct {
+	TraceID      string
+	SpanID       string
+	ParentSpanID string
+	Name         string
+	Service      string
+	Start        time.Time
+	End          time.Time
+	Error        bool
+}
+
+// SetTraceLookup sets the function returning the received spans of a trace,
+// ordered by start time
+func (m *DashboardModel) SetTraceLookup(lookup func(traceID string) []TraceSpan) {
+	m.traceLookup = lookup
+}
+
+// entryTraceID returns the trace ID of an entry, or "" if it has none
+func entryTraceID(entry LogEntry) string {
+	return strings.ToLower(strings.TrimSpace(entry.Attributes[TraceIDAttribute]))
+}
+
+// traceLogEntries returns the buffered log entries of a trace
+func (m *DashboardModel) traceLogEntries(traceID string) []LogEntry {
+	var entries []LogEntry
+	for _, entry := range m.allLogEntries {
+		if entryTraceID(entry) == traceID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// filterByTrace filters the log view to the entries of a trace
+func (m *DashboardModel) filterByTrace(traceID string) {
+	m.filterInput.SetValue(fmt.Sprintf("%s=%s", TraceIDAttribute, traceID))
+	m.applyFilterInput()
+	m.updateFilteredView()
+}
+
+// formatTraceDetails renders the trace section of the log details: the span
+// tree of the entry's trace and the other logs of the trace
+func (m *DashboardModel) formatTraceDetails(entry LogEntry, traceID string, maxWidth int) string {
+	grayStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	valueStyle := lipgloss.NewStyle().Foreground(ColorWhite)
+	errorStyle := lipgloss.NewStyle().Foreground(ColorRed)
+	currentStyle := lipgloss.NewStyle().Foreground(ColorGreen).Bold(true)
+
+	var details strings.Builder
+	details.WriteString(grayStyle.Render("Trace ID: ") + valueStyle.Render(traceID) + "\n")
+
+	// Span tree
+	var spans []TraceSpan
+	if m.traceLookup != nil {
+		spans = m.traceLookup(traceID)
+	}
+	switch {
+	case len(spans) > 0:
+		details.WriteString(grayStyle.Render(fmt.Sprintf("Spans (%d):", len(spans))) + "\n")
+		spanID := strings.ToLower(entry.Attributes[SpanIDAttribute])
+		for _, line := range spanTreeLines(spans) {
+			text := line.prefix + line.span.Name
+			if line.span.Service != "" {
+				text += "  " + grayStyle.Render(line.span.Service)
+			}
+			text += "  " + formatSpanDuration(line.span.End.Sub(line.span.Start))
+			if line.span.Error {
+				text += " " + errorStyle.Render("✗ error")
+			}
+			if line.span.SpanID == spanID {
+				text += "  " + currentStyle.Render("◀ this log")
+			}
+			details.WriteString(text + "\n")
+		}
+	case m.traceLookup != nil:
+		details.WriteString(grayStyle.Render("No spans received for this trace") + "\n")
+	default:
+		details.WriteString(grayStyle.Render("Send traces to the OTLP receiver (--otlp-enabled) to see its spans") + "\n")
+	}
+
+	// Logs of the same trace
+	entries := m.traceLogEntries(traceID)
+	details.WriteString("\n" + grayStyle.Render(fmt.Sprintf("Logs in this trace (%d):", len(entries))) + "\n")
+	start := max(len(entries)-maxTraceLogs, 0)
+	if start > 0 {
+		details.WriteString(grayStyle.Render(fmt.Sprintf("... %d earlier logs", start)) + "\n")
+	}
+	for _, traceEntry := range entries[start:] {
+		ts := traceEntry.OrigTimestamp
+		if ts.IsZero() {
+			ts = traceEntry.Timestamp
+		}
+		prefix := fmt.Sprintf("%s %-5s ", ts.Format("15:04:05.000"), normalizeSeverityLevel(traceEntry.Severity))
+		message := traceEntry.Message
+		if room := maxWidth - len(prefix) - 2; room > 3 && len(message) > room {
+			message = message[:room-3] + "..."
+		}
+		line := lipgloss.NewStyle().Foreground(getSeverityColor(normalizeSeverityLevel(traceEntry.Severity))).Render(prefix) + message
+		if traceEntry.Timestamp.Equal(entry.Timestamp) && traceEntry.Message == entry.Message {
+			line = currentStyle.Render("▶ ") + line
+		} else {
+			line = "  " + line
+		}
+		details.WriteString(line + "\n")
+	}
+
+	details.WriteString("\n" + grayStyle.Render("Press 't' to filter the log view by this trace") + "\n")
+	return details.String()
+}
+
+// spanTreeLine is a span with the tree prefix it is rendered with
+type spanTreeLine struct {
+	prefix string
+	span   TraceSpan
+}
+
+// spanTreeLines orders spans as a tree, children below their parent. Spans
+// whose parent was not received, or whose parents form a cycle, are shown as
+// roots.
+func spanTreeLines(spans []TraceSpan) []spanTreeLine {
+	known := make(map[string]bool, len(spans))
+	for _, span := range spans {
+		known[span.SpanID] = true
+	}
+	children := make(map[string][]TraceSpan)
+	var roots []TraceSpan
+	for _, span := range spans {
+		if span.ParentSpanID == "" || !known[span.ParentSpanID] || span.ParentSpanID == span.SpanID {
+			roots = append(roots, span)
+		} else {
+			children[span.ParentSpanID] = append(children[span.ParentSpanID], span)
+		}
+	}
+
+	var lines []spanTreeLine
+	visited := make(map[string]bool, len(spans))
+	var walk func(span TraceSpan, prefix, childPrefix string)
+	walk = func(span TraceSpan, prefix, childPrefix string) {
+		if visited[span.SpanID] {
+			return // Broken parent links must not loop
+		}
+		visited[span.SpanID] = true
+		lines = append(lines, spanTreeLine{prefix: prefix, span: span})
+
+		kids := children[span.SpanID]
+		for i, child := range kids {
+			if i == len(kids)-1 {
+				walk(child, childPrefix+"└─ ", childPrefix+"   ")
+			} else {
+				walk(child, childPrefix+"├─ ", childPrefix+"│  ")
+			}
+		}
+	}
+	for _, root := range roots {
+		walk(root, "", "")
+	}
+	// Spans in a parent cycle are not below any root
+	for _, span := range spans {
+		if !visited[span.SpanID] {
+			walk(span, "", "")
+		}
+	}
+	return lines
+}
+
+// formatSpanDuration formats a span duration, e.g. 850µs, 12.3ms or 1.25s
+func formatSpanDuration(d time.Duration) string {
+	switch {
+	case d < time.Millisecond:
+		return fmt.Sprintf("%dµs", d.Microseconds())
+	case d < time.Second:
+		return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+	default:
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	}
+}
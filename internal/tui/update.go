@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
@@ -28,6 +29,13 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case UpdateMsg:
 		return m.handleUpdate(msg)
 
+	case StatusNoticeMsg:
+		m.statusNotice = string(msg)
+		m.statusNoticeTime = time.Now()
+
+	case ReplayStatusMsg:
+		m.replayStatus = &msg
+
 	case ManualResetMsg:
 		// Handle manual reset - the actual reset will be done in the app layer
 		// Just pass it up the chain
@@ -147,6 +155,11 @@ func (m *DashboardModel) handleMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd)
 		return m.handleCountsModalMouseEvent(msg)
 	}
 	
+	// Ignore mouse events while the export, correlation or numeric modal is open
+	if m.showExportModal || m.showCorrelationModal || m.showNumericModal {
+		return m, nil
+	}
+
 	// Handle mouse events in log viewer modal
 	if m.showLogViewerModal {
 		return m.handleLogViewerModalMouseEvent(msg)
@@ -576,10 +589,15 @@ func (m *DashboardModel) handleUpdate(msg UpdateMsg) (tea.Model, tea.Cmd) {
 				counts = &SeverityCounts{}
 			}
 			m.countsHistory = append(m.countsHistory, *counts)
+			anomalous := m.anomalyDetector.evaluate(time.Now(), m.updateInterval, *counts, m.servicesBySeverity, m.drain3Manager)
+			m.countsAnomalous = append(m.countsAnomalous, anomalous)
 			// Keep only last 50 data points
 			if len(m.countsHistory) > 50 {
 				m.countsHistory = m.countsHistory[1:]
 			}
+			if len(m.countsAnomalous) > len(m.countsHistory) {
+				m.countsAnomalous = m.countsAnomalous[len(m.countsAnomalous)-len(m.countsHistory):]
+			}
 			// Chart data updated in view rendering
 		}
 	}
@@ -611,9 +629,12 @@ func (m *DashboardModel) addLogEntry(entry LogEntry) {
 	
 	// Update lifetime statistics (unlimited tracking)
 	m.updateLifetimeStats(entry)
+	m.updateNumericAttributes(entry)
+	m.assignFileColor(entry)
 	
 	// Update heatmap data for counts modal
 	m.updateHeatmapData(entry)
+	m.updateEventHeatmap(entry)
 	
 	// Update services data for counts modal (patterns will be derived from drain3)
 	m.updateCountsModalServices(entry)
@@ -633,16 +654,26 @@ func (m *DashboardModel) addLogEntry(entry LogEntry) {
 	// Only process through drain3 and update view when not paused
 	if !m.viewPaused {
 		// Process through drain3 for pattern extraction
-		if m.drain3Manager != nil {
-			m.drain3Manager.AddLogMessage(entry.Message)
-			m.drain3LastProcessed = len(m.allLogEntries) // Track that we've processed up to here
-		}
+		m.processPendingPatterns()
 
 		// Update filtered view
 		m.updateFilteredView()
 	}
 }
 
+// processPendingPatterns runs the entries not processed yet (the new entry, or
+// the ones logged while paused) through drain3 and the anomaly detector
+func (m *DashboardModel) processPendingPatterns() {
+	if m.drain3Manager == nil {
+		return
+	}
+	for i := m.drain3LastProcessed; i < len(m.allLogEntries); i++ {
+		m.allLogEntries[i].PatternID = m.drain3Manager.AddLogMessage(m.allLogEntries[i].Message)
+		m.anomalyDetector.observeEntry(m.allLogEntries[i])
+	}
+	m.drain3LastProcessed = len(m.allLogEntries) // Track that we've processed up to here
+}
+
 // updateFilteredView regenerates the filtered log entries view
 func (m *DashboardModel) updateFilteredView() {
 	oldSelection := m.selectedLogIndex
@@ -652,8 +683,8 @@ func (m *DashboardModel) updateFilteredView() {
 
 	// Apply filter to all entries
 	for _, entry := range m.allLogEntries {
-		// Check regex filter (if any) - search in message, attributes keys, and attribute values
-		passesRegexFilter := m.filterRegex == nil || m.matchesFilter(entry)
+		// Check regex/query filter (if any) - regex searches message, attribute keys and values
+		passesRegexFilter := m.matchesFilter(entry)
 
 		// Check severity filter (if active)
 		// Normalize severity to match filter keys
@@ -714,6 +745,10 @@ func (m *DashboardModel) updateLifetimeStats(entry LogEntry) {
 	
 	// Update attribute counts
 	for key, value := range entry.Attributes {
+		if key == FileOffsetAttribute {
+			continue // Unique for every entry, counting it would only use memory
+		}
+
 		// Skip common keys that we handle separately for some stats
 		attrKey := fmt.Sprintf("%s=%s", key, value)
 		if len(attrKey) < 200 { // Only include reasonable length attributes
@@ -786,30 +821,48 @@ func (m *DashboardModel) updateProcessingRateStats() {
 // matchesFilter checks if a log entry matches the current regex filter
 // It searches in the message, attribute keys, and attribute values
 func (m *DashboardModel) matchesFilter(entry LogEntry) bool {
+	if m.filterQuery != nil {
+		return m.filterQuery.matches(entry)
+	}
 	if m.filterRegex == nil {
 		return true
 	}
 
-	// Check the raw log line (message)
-	if m.filterRegex.MatchString(entry.RawLine) {
+	// Check the raw line, processed message and all attribute keys and values
+	return entryMatchesRegex(entry, m.filterRegex)
+}
+
+// hasFilter returns true if a regex or query filter is applied
+func (m *DashboardModel) hasFilter() bool {
+	return m.filterRegex != nil || m.filterQuery != nil
+}
+
+// clearFilter removes any applied regex or query filter
+func (m *DashboardModel) clearFilter() {
+	m.filterRegex = nil
+	m.filterQuery = nil
+}
+
+// applyFilterInput parses the filter input as a query expression, falling back
+// to a plain regex. Returns false if the input is invalid as both, in which
+// case the previous filter is kept.
+func (m *DashboardModel) applyFilterInput() bool {
+	value := m.filterInput.Value()
+	if value == "" {
+		m.clearFilter()
 		return true
 	}
 
-	// Check the processed message
-	if m.filterRegex.MatchString(entry.Message) {
+	if query, err := parseFilterQuery(value); err == nil {
+		m.filterQuery = query
+		m.filterRegex = nil
 		return true
 	}
 
-	// Check all attribute keys and values
-	for key, value := range entry.Attributes {
-		// Check if the attribute key matches
-		if m.filterRegex.MatchString(key) {
-			return true
-		}
-		// Check if the attribute value matches
-		if m.filterRegex.MatchString(value) {
-			return true
-		}
+	if regex, err := regexp.Compile(value); err == nil {
+		m.filterRegex = regex
+		m.filterQuery = nil
+		return true
 	}
 
 	return false
@@ -887,7 +940,7 @@ func (m *DashboardModel) updateCountsModalServices(entry LogEntry) {
 	}
 	
 	// Update service counts by severity
-	serviceName := getServiceName(entry)
+	serviceName := GetServiceName(entry)
 	if serviceName != "" {
 		if m.servicesBySeverity[severity] == nil {
 			m.servicesBySeverity[severity] = make([]ServiceCount, 0)
@@ -920,8 +973,8 @@ func (m *DashboardModel) updateCountsModalServices(entry LogEntry) {
 	}
 }
 
-// getServiceName extracts service name from log entry attributes
-func getServiceName(entry LogEntry) string {
+// GetServiceName extracts service name from log entry attributes
+func GetServiceName(entry LogEntry) string {
 	// Try different common service attribute names
 	if service, ok := entry.Attributes["service"]; ok {
 		return service
@@ -938,6 +991,9 @@ func getServiceName(entry LogEntry) string {
 	if service, ok := entry.Attributes["application"]; ok {
 		return service
 	}
+	if container, ok := entry.Attributes["k8s.container.name"]; ok {
+		return container
+	}
 	
 	// Fallback to host if no service specified
 	if host, ok := entry.Attributes["host"]; ok {
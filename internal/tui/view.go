@@ -7,7 +7,7 @@ import (
 // hasFilterOrSearch returns true if a filter or search is active or applied
 func (m *DashboardModel) hasFilterOrSearch() bool {
 	return m.filterActive || m.searchActive || 
-		m.filterRegex != nil || m.filterInput.Value() != "" || 
+		m.hasFilter() || m.filterInput.Value() != "" || 
 		m.searchTerm != "" || m.searchInput.Value() != ""
 }
 
@@ -42,6 +42,21 @@ func (m *DashboardModel) View() string {
 		return m.renderSeverityFilterModal()
 	}
 
+	// Show correlation modal (check before log viewer so it can overlay)
+	if m.showCorrelationModal {
+		return m.renderCorrelationModal()
+	}
+
+	// Show numeric attributes modal (check before log viewer so it can overlay)
+	if m.showNumericModal {
+		return m.renderNumericModal()
+	}
+
+	// Show export modal (check before log viewer so it can overlay)
+	if m.showExportModal {
+		return m.renderExportModal()
+	}
+
 	// Show log viewer modal (fullscreen log viewer)
 	if m.showLogViewerModal {
 		return m.renderLogViewerModal()
@@ -3,11 +3,15 @@ package vmlogs
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Client represents a Victoria Logs client for streaming logs
@@ -33,14 +37,35 @@ func NewClient(baseURL, user, password string) *Client {
 // Tail live-tails logs matching LogsQL. Optionally pass params like
 // start_offset=1h to replay recent history before live mode kicks in.
 func (c *Client) Tail(ctx context.Context, logsQL string, params map[string]string, onLine func(string) error) error {
-	endpoint := c.BaseURL + "/select/logsql/tail"
 	form := url.Values{}
 	form.Set("query", logsQL)
 	for k, v := range params {
 		form.Set(k, v)
 	}
+	return c.stream(ctx, "/select/logsql/tail", "tail", form, onLine)
+}
+
+// Query streams the logs matching LogsQL between start and end. A limit above
+// zero returns at most that many of the most recent matching logs.
+func (c *Client) Query(ctx context.Context, logsQL string, start, end time.Time, limit int, params map[string]string, onLine func(string) error) error {
+	form := url.Values{}
+	form.Set("query", logsQL)
+	for k, v := range params {
+		form.Set(k, v)
+	}
+	form.Set("start", start.Format(time.RFC3339Nano))
+	if !end.IsZero() {
+		form.Set("end", end.Format(time.RFC3339Nano))
+	}
+	if limit > 0 {
+		form.Set("limit", strconv.Itoa(limit))
+	}
+	return c.stream(ctx, "/select/logsql/query", "query", form, onLine)
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+// stream posts a form to an endpoint and passes every NDJSON line of the response to onLine
+func (c *Client) stream(ctx context.Context, path, operation string, form url.Values, onLine func(string) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+path, strings.NewReader(form.Encode()))
 	if err != nil {
 		return err
 	}
@@ -63,7 +88,7 @@ func (c *Client) Tail(ctx context.Context, logsQL string, params map[string]stri
 
 	if resp.StatusCode/100 != 2 {
 		b, _ := io.ReadAll(io.LimitReader(resp.Body, 8192))
-		return errors.New("tail failed: " + resp.Status + " - " + string(b))
+		return errors.New(operation + " failed: " + resp.Status + " - " + string(b))
 	}
 
 	return scanNDJSON(resp.Body, onLine)
@@ -99,6 +124,7 @@ type Receiver struct {
 	client     *Client
 	query      string
 	params     map[string]string
+	history    History
 	lineChan   chan string
 	ctx        context.Context
 	cancelFunc context.CancelFunc
@@ -119,6 +145,12 @@ func NewReceiver(baseURL, user, password, query string, params map[string]string
 	}
 }
 
+// SetHistory makes the receiver query past logs first. Without Follow the
+// receiver stops once they are all read. Call before Start.
+func (r *Receiver) SetHistory(history History) {
+	r.history = history
+}
+
 // Start begins streaming logs from Victoria Logs
 func (r *Receiver) Start() error {
 	go func() {
@@ -133,7 +165,7 @@ func (r *Receiver) Start() error {
 			return nil
 		}
 
-		if err := r.client.Tail(r.ctx, r.query, r.params, onLine); err != nil && !errors.Is(err, context.Canceled) {
+		if err := r.stream(onLine); err != nil && !errors.Is(err, context.Canceled) {
 			// Silently ignore streaming errors to avoid UI interference
 			// The receiver will stop gracefully
 		}
@@ -142,6 +174,51 @@ func (r *Receiver) Start() error {
 	return nil
 }
 
+// stream queries the history, if any, and then tails new logs unless only the history was asked for
+func (r *Receiver) stream(onLine func(string) error) error {
+	if r.history.Start.IsZero() {
+		return r.client.Tail(r.ctx, r.query, r.params, onLine)
+	}
+
+	// Pin the end, so that tailing can pick up the logs written after it
+	end := r.history.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if err := r.client.Query(r.ctx, r.query, r.history.Start, end, r.history.Limit, r.params, onLine); err != nil {
+		return err
+	}
+	if !r.history.Follow {
+		return nil
+	}
+
+	// Tailing starts at connect time, so replay the logs written since the end
+	// of the query and drop the ones the query already returned
+	params := make(map[string]string, len(r.params)+1)
+	for k, v := range r.params {
+		params[k] = v
+	}
+	params["start_offset"] = strconv.Itoa(int(math.Ceil(time.Since(end).Seconds()))+1) + "s"
+	return r.client.Tail(r.ctx, r.query, params, func(line string) error {
+		if t, ok := lineTime(line); ok && !t.After(end) {
+			return nil
+		}
+		return onLine(line)
+	})
+}
+
+// lineTime returns the _time field of an NDJSON log line
+func lineTime(line string) (time.Time, bool) {
+	var fields struct {
+		Time string `json:"_time"`
+	}
+	if err := json.Unmarshal([]byte(line), &fields); err != nil || fields.Time == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, fields.Time)
+	return t, err == nil
+}
+
 // Stop stops the Victoria Logs receiver
 func (r *Receiver) Stop() {
 	if r.cancelFunc != nil {
@@ -0,0 +1,196 @@
+package vmlogs
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubServer serves NDJSON lines for the query and tail endpoints and records the requests
+type stubServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []stubRequest
+}
+
+// stubRequest is a request received by the stub server
+type stubRequest struct {
+	path string
+	form url.Values
+}
+
+func newStubServer(t *testing.T, responses map[string][]string) *stubServer {
+	t.Helper()
+	stub := &stubServer{}
+	stub.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		stub.mu.Lock()
+		stub.requests = append(stub.requests, stubRequest{path: r.URL.Path, form: r.PostForm})
+		stub.mu.Unlock()
+
+		lines, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/stream+json")
+		for _, line := range lines {
+			fmt.Fprintln(w, line)
+		}
+	}))
+	t.Cleanup(stub.Close)
+	return stub
+}
+
+func (s *stubServer) received() []stubRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]stubRequest(nil), s.requests...)
+}
+
+// logLine returns an NDJSON log line as Victoria Logs sends it
+func logLine(t time.Time, msg string) string {
+	return fmt.Sprintf(`{"_time":%q,"_msg":%q}`, t.UTC().Format(time.RFC3339Nano), msg)
+}
+
+// collect reads the receiver's lines until the channel closes
+func collect(t *testing.T, r *Receiver) []string {
+	t.Helper()
+	var lines []string
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case line, ok := <-r.GetLineChan():
+			if !ok {
+				return lines
+			}
+			lines = append(lines, line)
+		case <-timeout:
+			r.Stop()
+			t.Fatalf("receiver did not finish, got %d lines", len(lines))
+		}
+	}
+}
+
+// messages extracts the _msg values of NDJSON lines
+func messages(lines []string) []string {
+	var msgs []string
+	for _, line := range lines {
+		i := strings.Index(line, `"_msg":"`)
+		msgs = append(msgs, strings.TrimSuffix(line[i+len(`"_msg":"`):], `"}`))
+	}
+	return msgs
+}
+
+func TestReceiverQueriesHistory(t *testing.T) {
+	start := time.Date(2024, 5, 1, 14, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	stub := newStubServer(t, map[string][]string{
+		"/select/logsql/query": {
+			logLine(start.Add(time.Minute), "first"),
+			logLine(start.Add(2*time.Minute), "second"),
+			"",
+			logLine(start.Add(3*time.Minute), "third"),
+		},
+	})
+
+	r := NewReceiver(stub.URL, "", "", "level:error", nil)
+	r.SetHistory(History{Start: start, End: end, Limit: 500})
+	if err := r.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := messages(collect(t, r))
+	if want := []string{"first", "second", "third"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("lines = %v, want %v", got, want)
+	}
+
+	requests := stub.received()
+	if len(requests) != 1 || requests[0].path != "/select/logsql/query" {
+		t.Fatalf("requests = %v, want a single query", requests)
+	}
+	form := requests[0].form
+	for key, want := range map[string]string{
+		"query": "level:error",
+		"start": start.Format(time.RFC3339Nano),
+		"end":   end.Format(time.RFC3339Nano),
+		"limit": "500",
+	} {
+		if got := form.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestReceiverFollowsHistory(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	stub := newStubServer(t, map[string][]string{
+		"/select/logsql/query": {
+			logLine(start.Add(time.Minute), "history"),
+			logLine(start.Add(2*time.Minute), "last history"),
+		},
+		"/select/logsql/tail": {
+			logLine(start.Add(2*time.Minute), "last history"), // Replayed, already queried
+			logLine(time.Now().Add(time.Minute), "live"),
+		},
+	})
+
+	r := NewReceiver(stub.URL, "", "", "*", map[string]string{"refresh_interval": "1s"})
+	r.SetHistory(History{Start: start, Follow: true})
+	if err := r.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := messages(collect(t, r))
+	if want := []string{"history", "last history", "live"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("lines = %v, want %v", got, want)
+	}
+
+	requests := stub.received()
+	if len(requests) != 2 || requests[0].path != "/select/logsql/query" || requests[1].path != "/select/logsql/tail" {
+		t.Fatalf("requests = %v, want a query followed by a tail", requests)
+	}
+	if requests[0].form.Get("end") == "" {
+		t.Error("query end is not pinned")
+	}
+	if requests[0].form.Get("limit") != "" {
+		t.Errorf("limit = %q, want none", requests[0].form.Get("limit"))
+	}
+	tail := requests[1].form
+	if tail.Get("start_offset") == "" {
+		t.Error("tail has no start_offset to cover the logs written after the query")
+	}
+	if tail.Get("refresh_interval") != "1s" {
+		t.Errorf("refresh_interval = %q, want the receiver params", tail.Get("refresh_interval"))
+	}
+}
+
+func TestReceiverTailsWithoutHistory(t *testing.T) {
+	stub := newStubServer(t, map[string][]string{
+		"/select/logsql/tail": {logLine(time.Now(), "live")},
+	})
+
+	r := NewReceiver(stub.URL, "", "", "*", nil)
+	if err := r.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := messages(collect(t, r)); len(got) != 1 || got[0] != "live" {
+		t.Errorf("lines = %v, want [live]", got)
+	}
+	requests := stub.received()
+	if len(requests) != 1 || requests[0].path != "/select/logsql/tail" {
+		t.Fatalf("requests = %v, want a single tail", requests)
+	}
+	if requests[0].form.Has("start_offset") {
+		t.Error("plain tailing should not replay history")
+	}
+}
@@ -0,0 +1,52 @@
+package vmlogs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// History selects past logs that the receiver queries before, or instead of,
+// live tailing
+type History struct {
+	Start  time.Time // Zero disables the history query
+	End    time.Time // Zero queries up to now
+	Limit  int       // Maximum number of logs (the most recent ones), 0 for no limit
+	Follow bool      // Continue with live tailing once the query is done
+}
+
+// timeLayouts are the absolute time formats accepted by ParseTime, besides RFC 3339
+var timeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTime parses a --vmlogs-start or --vmlogs-end value: an RFC 3339 time, a
+// local date and time like 2006-01-02 15:04, a Unix timestamp in seconds, or a
+// duration before now like 2h or 30m. Empty values give the zero time.
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	if ago, err := time.ParseDuration(value); err == nil && ago > 0 {
+		return now.Add(-ago), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q (use e.g. 2h, 2024-05-01 14:00 or 2024-05-01T14:00:00Z)", value)
+}